@@ -46,8 +46,13 @@ func (b *BitwiseBuiltinRunner) Name() string {
 	return BITWISE_BUILTIN_NAME
 }
 
-func (b *BitwiseBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) {
-	b.base = segments.AddSegment()
+func (b *BitwiseBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) error {
+	base, err := segments.AddSegment()
+	if err != nil {
+		return err
+	}
+	b.base = base
+	return nil
 }
 
 func (b *BitwiseBuiltinRunner) InitialStack() []memory.MaybeRelocatable {
@@ -103,6 +108,10 @@ func (b *BitwiseBuiltinRunner) Include(include bool) {
 	b.included = include
 }
 
+func (b *BitwiseBuiltinRunner) IsIncluded() bool {
+	return b.included
+}
+
 func (b *BitwiseBuiltinRunner) Ratio() uint {
 	return b.ratio
 }
@@ -129,7 +138,7 @@ func (b *BitwiseBuiltinRunner) GetAllocatedMemoryUnits(segments *memory.MemorySe
 
 	minStep := b.ratio * b.instancesPerComponent
 	if currentStep < minStep {
-		return 0, memory.InsufficientAllocatedCellsErrorMinStepNotReached(minStep, b.Name())
+		return 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_MIN_STEP, Builtin: b.Name(), Allocated: minStep}
 	}
 	value, err := utils.SafeDiv(currentStep, b.ratio)
 
@@ -152,7 +161,7 @@ func (b *BitwiseBuiltinRunner) GetUsedCellsAndAllocatedSizes(segments *memory.Me
 	}
 
 	if used > size {
-		return 0, 0, memory.InsufficientAllocatedCellsErrorWithBuiltinName(b.Name(), used, size)
+		return 0, 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_BUILTIN, Builtin: b.Name(), Used: used, Allocated: size}
 	}
 
 	return used, size, nil
@@ -245,11 +254,21 @@ func (r *BitwiseBuiltinRunner) FinalStack(segments *memory.MemorySegmentManager,
 	}
 }
 
+func (r *BitwiseBuiltinRunner) GetStopPtr() *uint {
+	return r.StopPtr
+}
+
 func (r *BitwiseBuiltinRunner) GetUsedInstances(segments *memory.MemorySegmentManager) (uint, error) {
 	usedCells, err := segments.GetSegmentUsedSize(uint(r.Base().SegmentIndex))
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
-	return utils.DivCeil(usedCells, r.CellsPerInstance()), nil
+	return InstancesFromCells(usedCells, r.CellsPerInstance()), nil
+}
+
+func (r *BitwiseBuiltinRunner) Clone() BuiltinRunner {
+	clone := *r
+	clone.StopPtr = cloneStopPtr(r.StopPtr)
+	return &clone
 }
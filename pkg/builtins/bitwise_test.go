@@ -182,3 +182,18 @@ func TestGetUsedDilutedCheckUnitsC(t *testing.T) {
 		t.Errorf("Wrong Value for GetUsedDilutedChecks, should be %d, got %d", expected, result)
 	}
 }
+
+func TestGetUsedInstancesBitwise(t *testing.T) {
+	builtin := builtins.NewBitwiseBuiltinRunner(256)
+	segments := memory.NewMemorySegmentManager()
+	builtin.InitializeSegments(&segments)
+	segments.SegmentUsedSizes[0] = 12
+
+	usedInstances, err := builtin.GetUsedInstances(&segments)
+	if err != nil {
+		t.Errorf("GetUsedInstances failed with error: %s", err)
+	}
+	if usedInstances != 3 {
+		t.Errorf("Wrong used instances in %s builtin. Expected 3, got %d", builtin.Name(), usedInstances)
+	}
+}
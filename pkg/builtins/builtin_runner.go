@@ -3,6 +3,7 @@ package builtins
 import (
 	"fmt"
 
+	"github.com/lambdaclass/cairo-vm.go/pkg/utils"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
 	"github.com/pkg/errors"
 )
@@ -29,7 +30,7 @@ type BuiltinRunner interface {
 	// Returns the name of the builtin
 	Name() string
 	// Creates a memory segment for the builtin and initializes its base
-	InitializeSegments(*memory.MemorySegmentManager)
+	InitializeSegments(*memory.MemorySegmentManager) error
 	// Returns the builtin's initial stack
 	InitialStack() []memory.MaybeRelocatable
 	// Attempts to deduce the value of a memory cell given by its address. Can return either a nil pointer and an error, if an error arises during the deduction,
@@ -40,6 +41,8 @@ type BuiltinRunner interface {
 	AddValidationRule(*memory.Memory)
 	// Sets the inclusion of the Builtin Runner in the Cairo Runner
 	Include(bool)
+	// Returns true if the builtin is used by the program. Builtins that aren't included only exist to fill the layout in proof mode
+	IsIncluded() bool
 	// TODO: Later additions -> Some of them could depend on a Default Implementation
 	// // Most of them depend on Layouts being implemented
 	// // Use cases:
@@ -55,12 +58,37 @@ type BuiltinRunner interface {
 	GetUsedDilutedCheckUnits(dilutedSpacing uint, dilutedNBits uint) uint
 	GetUsedCellsAndAllocatedSizes(segments *memory.MemorySegmentManager, currentStep uint) (uint, uint, error)
 	FinalStack(segments *memory.MemorySegmentManager, pointer memory.Relocatable) (memory.Relocatable, error)
+	// Returns the builtin's stop pointer (the offset of its segment where the run stopped using it)
+	// It is set by FinalStack, and is nil before FinalStack is called
+	GetStopPtr() *uint
 	// // II. SECURITY (secure-run flag cairo-run || verify-secure flag run_from_entrypoint)
 	// RunSecurityChecks(*vm.VirtualMachine) error // verify_secure_runner logic
 	// // Returns the base & stop_ptr, stop_ptr can be nil
 	// GetMemorySegmentAddresses() (memory.Relocatable, *memory.Relocatable) //verify_secure_runner logic
 	// // III. STARKNET-SPECIFIC
 	GetUsedInstances(*memory.MemorySegmentManager) (uint, error)
+	// Returns the amount of memory cells used by each builtin instance
+	CellsPerInstance() uint
+	// Returns a deep copy of the builtin runner, sharing no mutable state with the original
+	Clone() BuiltinRunner
 	// // IV. GENERAL CASE (but not critical)
 	// FinalStack(*memory.MemorySegmentManager, memory.Relocatable) (memory.Relocatable, error) // read_return_values
 }
+
+// Returns a copy of the stop pointer that doesn't alias the original
+func cloneStopPtr(stopPtr *uint) *uint {
+	if stopPtr == nil {
+		return nil
+	}
+	stopPtrCopy := *stopPtr
+	return &stopPtrCopy
+}
+
+// Returns the amount of builtin instances spanned by usedCells memory cells,
+// counting a partially filled instance as a whole one
+func InstancesFromCells(usedCells uint, cellsPerInstance uint) uint {
+	if usedCells == 0 {
+		return 0
+	}
+	return utils.DivCeil(usedCells, cellsPerInstance)
+}
@@ -0,0 +1,46 @@
+package builtins_test
+
+import (
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func TestInstancesFromCellsPartialInstance(t *testing.T) {
+	// 7 cells of a 3-cell builtin span two full instances and a partial one
+	instances := builtins.InstancesFromCells(7, 3)
+	if instances != 3 {
+		t.Errorf("Wrong amount of instances. Expected 3, got %d", instances)
+	}
+}
+
+func TestInstancesFromCellsFullInstances(t *testing.T) {
+	instances := builtins.InstancesFromCells(9, 3)
+	if instances != 3 {
+		t.Errorf("Wrong amount of instances. Expected 3, got %d", instances)
+	}
+}
+
+func TestInstancesFromCellsNoCells(t *testing.T) {
+	instances := builtins.InstancesFromCells(0, 3)
+	if instances != 0 {
+		t.Errorf("Wrong amount of instances. Expected 0, got %d", instances)
+	}
+}
+
+func TestGetUsedInstancesWithUsedSizeOverride(t *testing.T) {
+	builtin := builtins.NewRangeCheckBuiltinRunner(8)
+	segments := memory.NewMemorySegmentManager()
+	builtin.InitializeSegments(&segments)
+	segments.ComputeEffectiveSizes()
+	segments.SetSegmentUsedSizeForTest(uint(builtin.Base().SegmentIndex), 5)
+	instances, err := builtin.GetUsedInstances(&segments)
+	if err != nil {
+		t.Errorf("GetUsedInstances failed with error: %s", err)
+		return
+	}
+	if instances != 5 {
+		t.Errorf("Wrong amount of instances. Expected 5, got %d", instances)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"math/big"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
-	"github.com/lambdaclass/cairo-vm.go/pkg/math_utils"
 	"github.com/lambdaclass/cairo-vm.go/pkg/utils"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
 	"github.com/pkg/errors"
@@ -87,7 +86,7 @@ func (r *EcOpBuiltinRunner) GetAllocatedMemoryUnits(segments *memory.MemorySegme
 
 	minStep := r.ratio * r.instancesPerComponent
 	if currentStep < minStep {
-		return 0, memory.InsufficientAllocatedCellsErrorMinStepNotReached(minStep, r.Name())
+		return 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_MIN_STEP, Builtin: r.Name(), Allocated: minStep}
 	}
 	value, err := utils.SafeDiv(currentStep, r.ratio)
 
@@ -110,7 +109,7 @@ func (r *EcOpBuiltinRunner) GetUsedCellsAndAllocatedSizes(segments *memory.Memor
 	}
 
 	if used > size {
-		return 0, 0, memory.InsufficientAllocatedCellsErrorWithBuiltinName(r.Name(), used, size)
+		return 0, 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_BUILTIN, Builtin: r.Name(), Used: used, Allocated: size}
 	}
 
 	return used, size, nil
@@ -158,8 +157,13 @@ func (ec *EcOpBuiltinRunner) Name() string {
 	return EC_OP_BUILTIN_NAME
 }
 
-func (ec *EcOpBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) {
-	ec.base = segments.AddSegment()
+func (ec *EcOpBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) error {
+	base, err := segments.AddSegment()
+	if err != nil {
+		return err
+	}
+	ec.base = base
+	return nil
 }
 
 func (ec *EcOpBuiltinRunner) InitialStack() []memory.MaybeRelocatable {
@@ -174,6 +178,10 @@ func (ec *EcOpBuiltinRunner) Include(include bool) {
 	ec.included = include
 }
 
+func (ec *EcOpBuiltinRunner) IsIncluded() bool {
+	return ec.included
+}
+
 func (ec *EcOpBuiltinRunner) DeduceMemoryCell(address memory.Relocatable, mem *memory.Memory) (*memory.MaybeRelocatable, error) {
 	EC_POINT_INDICES := [3]EcPoint{{x: 0, y: 1}, {x: 2, y: 3}, {x: 5, y: 6}}
 	OUTPUT_INDICES := EC_POINT_INDICES[2]
@@ -263,7 +271,7 @@ func LineSlope(point_a PartialSumB, point_b DoublePointB, prime big.Int) (big.In
 	n := new(big.Int).Sub(&point_a.Y, &point_b.Y)
 	m := new(big.Int).Sub(&point_a.X, &point_b.X)
 
-	z, err := math_utils.DivMod(n, m, &prime)
+	z, err := utils.DivMod(n, m, &prime)
 	if err != nil {
 		return big.Int{}, err
 	}
@@ -299,7 +307,7 @@ func EcDoubleSlope(point DoublePointB, alpha big.Int, prime big.Int) (big.Int, e
 	n.Add(n, &alpha)
 
 	m := new(big.Int).Mul(&point.Y, big.NewInt(2))
-	z, err := math_utils.DivMod(n, m, &prime)
+	z, err := utils.DivMod(n, m, &prime)
 
 	if err != nil {
 		return big.Int{}, err
@@ -398,11 +406,25 @@ func (r *EcOpBuiltinRunner) FinalStack(segments *memory.MemorySegmentManager, po
 	}
 }
 
+func (r *EcOpBuiltinRunner) GetStopPtr() *uint {
+	return r.StopPtr
+}
+
 func (r *EcOpBuiltinRunner) GetUsedInstances(segments *memory.MemorySegmentManager) (uint, error) {
 	usedCells, err := segments.GetSegmentUsedSize(uint(r.Base().SegmentIndex))
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
-	return utils.DivCeil(usedCells, r.CellsPerInstance()), nil
+	return InstancesFromCells(usedCells, r.CellsPerInstance()), nil
+}
+
+func (r *EcOpBuiltinRunner) Clone() BuiltinRunner {
+	clone := *r
+	clone.StopPtr = cloneStopPtr(r.StopPtr)
+	clone.cache = make(map[memory.Relocatable]lambdaworks.Felt, len(r.cache))
+	for addr, value := range r.cache {
+		clone.cache[addr] = value
+	}
+	return &clone
 }
@@ -217,3 +217,18 @@ func TestDeduceMemoryCellEcOpForPresetMemoryNonIntegerInput(t *testing.T) {
 // 		t.Errorf("TestIntegrationBitwise failed with error:\n %v", err)
 // 	}
 // }
+
+func TestGetUsedInstancesEcOp(t *testing.T) {
+	builtin := builtins.NewEcOpBuiltinRunner(256)
+	segments := memory.NewMemorySegmentManager()
+	builtin.InitializeSegments(&segments)
+	segments.SegmentUsedSizes[0] = 14
+
+	usedInstances, err := builtin.GetUsedInstances(&segments)
+	if err != nil {
+		t.Errorf("GetUsedInstances failed with error: %s", err)
+	}
+	if usedInstances != 2 {
+		t.Errorf("Wrong used instances in %s builtin. Expected 2, got %d", builtin.Name(), usedInstances)
+	}
+}
@@ -0,0 +1,140 @@
+package builtins
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+const GAS_BUILTIN_NAME = "gas_builtin"
+const GAS_CELLS_PER_INSTANCE = 0
+
+var ErrOutOfGas = errors.New("Out of gas")
+
+// Gas builtin used by Cairo 1 programs.
+// Unlike the other builtins it doesn't use its memory segment: the remaining gas is passed to the
+// entrypoint as a felt in the initial stack, and read back from the final stack once the run ends
+type GasBuiltinRunner struct {
+	base         memory.Relocatable
+	included     bool
+	StopPtr      *uint
+	RemainingGas uint64
+}
+
+func NewGasBuiltinRunner(initialGas uint64) *GasBuiltinRunner {
+	return &GasBuiltinRunner{RemainingGas: initialGas}
+}
+
+// Withdraws the given amount of gas, failing with ErrOutOfGas (and withdrawing nothing) if there isn't enough gas left
+func (r *GasBuiltinRunner) WithdrawGas(amount uint64) error {
+	if amount > r.RemainingGas {
+		return errors.Wrapf(ErrOutOfGas, "requested %d, remaining %d", amount, r.RemainingGas)
+	}
+	r.RemainingGas -= amount
+	return nil
+}
+
+func (r *GasBuiltinRunner) Base() memory.Relocatable {
+	return r.base
+}
+
+func (r *GasBuiltinRunner) Name() string {
+	return GAS_BUILTIN_NAME
+}
+
+func (r *GasBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) error {
+	base, err := segments.AddSegment()
+	if err != nil {
+		return err
+	}
+	r.base = base
+	return nil
+}
+
+func (r *GasBuiltinRunner) InitialStack() []memory.MaybeRelocatable {
+	if r.included {
+		return []memory.MaybeRelocatable{*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(r.RemainingGas))}
+	}
+	return []memory.MaybeRelocatable{}
+}
+
+func (r *GasBuiltinRunner) DeduceMemoryCell(rel memory.Relocatable, mem *memory.Memory) (*memory.MaybeRelocatable, error) {
+	return nil, nil
+}
+
+func (r *GasBuiltinRunner) AddValidationRule(mem *memory.Memory) {}
+
+func (r *GasBuiltinRunner) Include(include bool) {
+	r.included = include
+}
+
+func (r *GasBuiltinRunner) IsIncluded() bool {
+	return r.included
+}
+
+func (r *GasBuiltinRunner) Ratio() uint {
+	return 0
+}
+
+func (r *GasBuiltinRunner) CellsPerInstance() uint {
+	return GAS_CELLS_PER_INSTANCE
+}
+
+func (r *GasBuiltinRunner) GetAllocatedMemoryUnits(segments *memory.MemorySegmentManager, currentStep uint) (uint, error) {
+	return 0, nil
+}
+
+func (r *GasBuiltinRunner) GetUsedCellsAndAllocatedSizes(segments *memory.MemorySegmentManager, currentStep uint) (uint, uint, error) {
+	return 0, 0, nil
+}
+
+func (r *GasBuiltinRunner) GetRangeCheckUsage(memory *memory.Memory) (*uint, *uint) {
+	return nil, nil
+}
+
+func (r *GasBuiltinRunner) GetUsedPermRangeCheckLimits(segments *memory.MemorySegmentManager, currentStep uint) (uint, error) {
+	return 0, nil
+}
+
+func (r *GasBuiltinRunner) GetUsedDilutedCheckUnits(dilutedSpacing uint, dilutedNBits uint) uint {
+	return 0
+}
+
+func (r *GasBuiltinRunner) GetMemoryAccesses(manager *memory.MemorySegmentManager) ([]memory.Relocatable, error) {
+	return []memory.Relocatable{}, nil
+}
+
+// Reads the remaining gas from the final stack
+func (r *GasBuiltinRunner) FinalStack(segments *memory.MemorySegmentManager, pointer memory.Relocatable) (memory.Relocatable, error) {
+	r.StopPtr = new(uint)
+	if !r.included {
+		return pointer, nil
+	}
+	if pointer.Offset == 0 {
+		return memory.Relocatable{}, NewErrNoStopPointer(r.Name())
+	}
+	remainingGasAddr := memory.NewRelocatable(pointer.SegmentIndex, pointer.Offset-1)
+	remainingGas, err := segments.Memory.GetFelt(remainingGasAddr)
+	if err != nil {
+		return memory.Relocatable{}, err
+	}
+	r.RemainingGas, err = remainingGas.ToU64()
+	if err != nil {
+		return memory.Relocatable{}, err
+	}
+	return remainingGasAddr, nil
+}
+
+func (r *GasBuiltinRunner) GetStopPtr() *uint {
+	return r.StopPtr
+}
+
+func (r *GasBuiltinRunner) GetUsedInstances(segments *memory.MemorySegmentManager) (uint, error) {
+	return 0, nil
+}
+
+func (r *GasBuiltinRunner) Clone() BuiltinRunner {
+	clone := *r
+	clone.StopPtr = cloneStopPtr(r.StopPtr)
+	return &clone
+}
@@ -0,0 +1,57 @@
+package builtins_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func TestGasWithdrawGas(t *testing.T) {
+	gas := builtins.NewGasBuiltinRunner(100)
+	err := gas.WithdrawGas(30)
+	if err != nil {
+		t.Errorf("WithdrawGas failed with error: %s", err)
+	}
+	if gas.RemainingGas != 70 {
+		t.Errorf("Wrong remaining gas. Expected 70, got %d", gas.RemainingGas)
+	}
+}
+
+func TestGasWithdrawGasExceedingRemaining(t *testing.T) {
+	gas := builtins.NewGasBuiltinRunner(100)
+	err := gas.WithdrawGas(101)
+	if !errors.Is(err, builtins.ErrOutOfGas) {
+		t.Errorf("Expected ErrOutOfGas, got %v", err)
+	}
+	if gas.RemainingGas != 100 {
+		t.Errorf("A failed withdrawal shouldn't change the remaining gas, got %d", gas.RemainingGas)
+	}
+}
+
+func TestGasInitialStackIncluded(t *testing.T) {
+	gas := builtins.NewGasBuiltinRunner(100)
+	gas.Include(true)
+	expectedStack := []memory.MaybeRelocatable{*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(100))}
+	if !reflect.DeepEqual(gas.InitialStack(), expectedStack) {
+		t.Errorf("Wrong initial stack")
+	}
+}
+
+func TestGasFinalStackReadsRemainingGas(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.Memory.Insert(memory.NewRelocatable(0, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(42)))
+	gas := builtins.NewGasBuiltinRunner(100)
+	gas.Include(true)
+	pointer, err := gas.FinalStack(&segments, memory.NewRelocatable(0, 1))
+	if err != nil {
+		t.Errorf("FinalStack failed with error: %s", err)
+	}
+	if pointer != memory.NewRelocatable(0, 0) || gas.RemainingGas != 42 {
+		t.Errorf("Wrong FinalStack result. Got pointer %v and remaining gas %d", pointer, gas.RemainingGas)
+	}
+}
@@ -44,8 +44,13 @@ func (k *KeccakBuiltinRunner) Name() string {
 	return KECCAK_BUILTIN_NAME
 }
 
-func (k *KeccakBuiltinRunner) InitializeSegments(segments *MemorySegmentManager) {
-	k.base = segments.AddSegment()
+func (k *KeccakBuiltinRunner) InitializeSegments(segments *MemorySegmentManager) error {
+	base, err := segments.AddSegment()
+	if err != nil {
+		return err
+	}
+	k.base = base
+	return nil
 }
 
 func (k *KeccakBuiltinRunner) InitialStack() []MaybeRelocatable {
@@ -527,10 +532,40 @@ func keccakF1600(a *[25]uint64) {
 	}
 }
 
+const KECCAK_256_RATE_BYTES = 136
+
+// Computes the Keccak-256 hash of the input, as used by Ethereum (original Keccak padding, not SHA3's)
+func Keccak256(input []byte) [32]byte {
+	// Pad the input with the multi-rate padding (0x01 ... 0x80) up to a multiple of the rate
+	paddedLen := (len(input)/KECCAK_256_RATE_BYTES + 1) * KECCAK_256_RATE_BYTES
+	padded := append(make([]byte, 0, paddedLen), input...)
+	padded = padded[:paddedLen]
+	padded[len(input)] ^= 0x01
+	padded[paddedLen-1] ^= 0x80
+
+	var state [25]uint64
+	for block := 0; block < paddedLen; block += KECCAK_256_RATE_BYTES {
+		for i := 0; i < KECCAK_256_RATE_BYTES/8; i++ {
+			state[i] ^= binary.LittleEndian.Uint64(padded[block+8*i : block+8*i+8])
+		}
+		keccakF1600(&state)
+	}
+
+	var hash [32]byte
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(hash[8*i:], state[i])
+	}
+	return hash
+}
+
 func (k *KeccakBuiltinRunner) Include(include bool) {
 	k.included = include
 }
 
+func (k *KeccakBuiltinRunner) IsIncluded() bool {
+	return k.included
+}
+
 func (k *KeccakBuiltinRunner) Ratio() uint {
 	return k.ratio
 }
@@ -557,7 +592,7 @@ func (k *KeccakBuiltinRunner) GetAllocatedMemoryUnits(segments *memory.MemorySeg
 
 	minStep := k.ratio * k.instancesPerComponent
 	if currentStep < minStep {
-		return 0, memory.InsufficientAllocatedCellsErrorMinStepNotReached(minStep, k.Name())
+		return 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_MIN_STEP, Builtin: k.Name(), Allocated: minStep}
 	}
 	value, err := utils.SafeDiv(currentStep, k.ratio)
 
@@ -580,7 +615,7 @@ func (k *KeccakBuiltinRunner) GetUsedCellsAndAllocatedSizes(segments *memory.Mem
 	}
 
 	if used > size {
-		return 0, 0, memory.InsufficientAllocatedCellsErrorWithBuiltinName(k.Name(), used, size)
+		return 0, 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_BUILTIN, Builtin: k.Name(), Used: used, Allocated: size}
 	}
 
 	return used, size, nil
@@ -668,11 +703,25 @@ func (r *KeccakBuiltinRunner) FinalStack(segments *memory.MemorySegmentManager,
 	}
 }
 
+func (r *KeccakBuiltinRunner) GetStopPtr() *uint {
+	return r.StopPtr
+}
+
 func (r *KeccakBuiltinRunner) GetUsedInstances(segments *memory.MemorySegmentManager) (uint, error) {
 	usedCells, err := segments.GetSegmentUsedSize(uint(r.Base().SegmentIndex))
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
-	return utils.DivCeil(usedCells, r.CellsPerInstance()), nil
+	return InstancesFromCells(usedCells, r.CellsPerInstance()), nil
+}
+
+func (r *KeccakBuiltinRunner) Clone() BuiltinRunner {
+	clone := *r
+	clone.StopPtr = cloneStopPtr(r.StopPtr)
+	clone.cache = make(map[Relocatable]Felt, len(r.cache))
+	for addr, value := range r.cache {
+		clone.cache[addr] = value
+	}
+	return &clone
 }
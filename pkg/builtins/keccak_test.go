@@ -1,6 +1,7 @@
 package builtins_test
 
 import (
+	"encoding/hex"
 	"reflect"
 	"testing"
 
@@ -167,3 +168,29 @@ func TestGetUsedDilutedCheckUnitsResult(t *testing.T) {
 	}
 
 }
+
+func TestGetUsedInstancesKeccak(t *testing.T) {
+	builtin := builtins.NewKeccakBuiltinRunner(2048)
+	segments := memory.NewMemorySegmentManager()
+	builtin.InitializeSegments(&segments)
+	segments.SegmentUsedSizes[0] = 20
+
+	usedInstances, err := builtin.GetUsedInstances(&segments)
+	if err != nil {
+		t.Errorf("GetUsedInstances failed with error: %s", err)
+	}
+	if usedInstances != 2 {
+		t.Errorf("Wrong used instances in %s builtin. Expected 2, got %d", builtin.Name(), usedInstances)
+	}
+}
+
+func TestKeccak256(t *testing.T) {
+	empty := builtins.Keccak256([]byte{})
+	if hex.EncodeToString(empty[:]) != "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470" {
+		t.Errorf("Wrong keccak256 of the empty input: %x", empty)
+	}
+	abc := builtins.Keccak256([]byte("abc"))
+	if hex.EncodeToString(abc[:]) != "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45" {
+		t.Errorf("Wrong keccak256 of \"abc\": %x", abc)
+	}
+}
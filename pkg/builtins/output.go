@@ -5,6 +5,7 @@ import (
 )
 
 const OUTPUT_BUILTIN_NAME = "output"
+const OUTPUT_CELLS_PER_INSTANCE = 1
 
 type OutputBuiltinRunner struct {
 	base     memory.Relocatable
@@ -24,8 +25,13 @@ func (o *OutputBuiltinRunner) Name() string {
 	return OUTPUT_BUILTIN_NAME
 }
 
-func (o *OutputBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) {
-	o.base = segments.AddSegment()
+func (o *OutputBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) error {
+	base, err := segments.AddSegment()
+	if err != nil {
+		return err
+	}
+	o.base = base
+	return nil
 }
 
 func (o *OutputBuiltinRunner) InitialStack() []memory.MaybeRelocatable {
@@ -45,10 +51,18 @@ func (o *OutputBuiltinRunner) Include(include bool) {
 	o.included = include
 }
 
+func (o *OutputBuiltinRunner) IsIncluded() bool {
+	return o.included
+}
+
 func (o *OutputBuiltinRunner) Ratio() uint {
 	return 0
 }
 
+func (o *OutputBuiltinRunner) CellsPerInstance() uint {
+	return OUTPUT_CELLS_PER_INSTANCE
+}
+
 func (o *OutputBuiltinRunner) GetAllocatedMemoryUnits(segments *memory.MemorySegmentManager, currentStep uint) (uint, error) {
 	return 0, nil
 }
@@ -125,11 +139,21 @@ func (r *OutputBuiltinRunner) FinalStack(segments *memory.MemorySegmentManager,
 	}
 }
 
+func (r *OutputBuiltinRunner) GetStopPtr() *uint {
+	return r.StopPtr
+}
+
 func (r *OutputBuiltinRunner) GetUsedInstances(segments *memory.MemorySegmentManager) (uint, error) {
 	usedCells, err := segments.GetSegmentUsedSize(uint(r.Base().SegmentIndex))
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
-	return usedCells, nil
+	return InstancesFromCells(usedCells, r.CellsPerInstance()), nil
+}
+
+func (r *OutputBuiltinRunner) Clone() BuiltinRunner {
+	clone := *r
+	clone.StopPtr = cloneStopPtr(r.StopPtr)
+	return &clone
 }
@@ -72,3 +72,18 @@ func TestGetAllocatedMemoryUnitsOutput(t *testing.T) {
 		t.Errorf("expected memory units to be 5, got: %d", mem_units)
 	}
 }
+
+func TestGetUsedInstancesOutput(t *testing.T) {
+	builtin := builtins.NewOutputBuiltinRunner()
+	segments := memory.NewMemorySegmentManager()
+	builtin.InitializeSegments(&segments)
+	segments.SegmentUsedSizes[0] = 4
+
+	usedInstances, err := builtin.GetUsedInstances(&segments)
+	if err != nil {
+		t.Errorf("GetUsedInstances failed with error: %s", err)
+	}
+	if usedInstances != 4 {
+		t.Errorf("Wrong used instances in %s builtin. Expected 4, got %d", builtin.Name(), usedInstances)
+	}
+}
@@ -35,6 +35,10 @@ func (r *PedersenBuiltinRunner) Include(include bool) {
 	r.included = include
 }
 
+func (r *PedersenBuiltinRunner) IsIncluded() bool {
+	return r.included
+}
+
 func (p *PedersenBuiltinRunner) Base() memory.Relocatable {
 	return p.base
 }
@@ -43,8 +47,13 @@ func (p *PedersenBuiltinRunner) Name() string {
 	return PEDERSEN_BUILTIN_NAME
 }
 
-func (p *PedersenBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) {
-	p.base = segments.AddSegment()
+func (p *PedersenBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) error {
+	base, err := segments.AddSegment()
+	if err != nil {
+		return err
+	}
+	p.base = base
+	return nil
 }
 
 func (p *PedersenBuiltinRunner) Ratio() uint {
@@ -125,7 +134,7 @@ func (p *PedersenBuiltinRunner) GetAllocatedMemoryUnits(segments *memory.MemoryS
 
 	minStep := p.ratio * p.instancesPerComponent
 	if currentStep < minStep {
-		return 0, memory.InsufficientAllocatedCellsErrorMinStepNotReached(minStep, p.Name())
+		return 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_MIN_STEP, Builtin: p.Name(), Allocated: minStep}
 	}
 	value, err := utils.SafeDiv(currentStep, p.ratio)
 
@@ -152,7 +161,7 @@ func (p *PedersenBuiltinRunner) GetUsedCellsAndAllocatedSizes(segments *memory.M
 	}
 
 	if used > size {
-		return 0, 0, memory.InsufficientAllocatedCellsErrorWithBuiltinName(p.Name(), used, size)
+		return 0, 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_BUILTIN, Builtin: p.Name(), Used: used, Allocated: size}
 	}
 
 	return used, size, nil
@@ -220,11 +229,22 @@ func (r *PedersenBuiltinRunner) FinalStack(segments *memory.MemorySegmentManager
 	}
 }
 
+func (r *PedersenBuiltinRunner) GetStopPtr() *uint {
+	return r.StopPtr
+}
+
 func (r *PedersenBuiltinRunner) GetUsedInstances(segments *memory.MemorySegmentManager) (uint, error) {
 	usedCells, err := segments.GetSegmentUsedSize(uint(r.Base().SegmentIndex))
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
-	return utils.DivCeil(usedCells, r.CellsPerInstance()), nil
+	return InstancesFromCells(usedCells, r.CellsPerInstance()), nil
+}
+
+func (r *PedersenBuiltinRunner) Clone() BuiltinRunner {
+	clone := *r
+	clone.StopPtr = cloneStopPtr(r.StopPtr)
+	clone.verified_addresses = append([]bool(nil), r.verified_addresses...)
+	return &clone
 }
@@ -1,6 +1,7 @@
 package builtins_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -45,3 +46,54 @@ func TestPedersenDeduceMemoryCell(t *testing.T) {
 	}
 
 }
+
+func TestGetUsedInstancesPedersen(t *testing.T) {
+	builtin := builtins.NewPedersenBuiltinRunner(256)
+	segments := memory.NewMemorySegmentManager()
+	builtin.InitializeSegments(&segments)
+	segments.SegmentUsedSizes[0] = 9
+
+	usedInstances, err := builtin.GetUsedInstances(&segments)
+	if err != nil {
+		t.Errorf("GetUsedInstances failed with error: %s", err)
+	}
+	if usedInstances != 3 {
+		t.Errorf("Wrong used instances in %s builtin. Expected 3, got %d", builtin.Name(), usedInstances)
+	}
+}
+
+func TestGetUsedInstancesPedersenMissingSegment(t *testing.T) {
+	builtin := builtins.NewPedersenBuiltinRunner(256)
+	segments := memory.NewMemorySegmentManager()
+	builtin.InitializeSegments(&segments)
+
+	// The builtin's segment doesn't exist in this memory
+	otherSegments := memory.NewMemorySegmentManager()
+	_, err := builtin.GetUsedInstances(&otherSegments)
+	if !errors.Is(err, memory.ErrSegmentNotFound) {
+		t.Errorf("GetUsedInstances should have failed with ErrSegmentNotFound, got %v", err)
+	}
+}
+
+func TestGetStopPtrPedersenAfterFinalStack(t *testing.T) {
+	pedersen := builtins.NewPedersenBuiltinRunner(256)
+	pedersen.Include(true)
+	segments := memory.NewMemorySegmentManager()
+	pedersen.InitializeSegments(&segments)
+	segments.AddSegment()
+	segments.SegmentUsedSizes[0] = 6
+	// The builtin's final pointer is stored at the end of the stack
+	segments.Memory.Insert(memory.NewRelocatable(1, 0), memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(0, 6)))
+
+	if pedersen.GetStopPtr() != nil {
+		t.Errorf("Stop pointer should be nil before FinalStack is called")
+	}
+	_, err := pedersen.FinalStack(&segments, memory.NewRelocatable(1, 1))
+	if err != nil {
+		t.Errorf("FinalStack failed with error: %s", err)
+	}
+	stopPtr := pedersen.GetStopPtr()
+	if stopPtr == nil || *stopPtr != 6 {
+		t.Errorf("Stop pointer should be equal to the used cells (6), got %v", stopPtr)
+	}
+}
@@ -33,8 +33,13 @@ func (p *PoseidonBuiltinRunner) Name() string {
 	return POSEIDON_BUILTIN_NAME
 }
 
-func (p *PoseidonBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) {
-	p.base = segments.AddSegment()
+func (p *PoseidonBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) error {
+	base, err := segments.AddSegment()
+	if err != nil {
+		return err
+	}
+	p.base = base
+	return nil
 }
 
 func (p *PoseidonBuiltinRunner) InitialStack() []memory.MaybeRelocatable {
@@ -88,6 +93,10 @@ func (p *PoseidonBuiltinRunner) Include(include bool) {
 	p.included = include
 }
 
+func (p *PoseidonBuiltinRunner) IsIncluded() bool {
+	return p.included
+}
+
 func (p *PoseidonBuiltinRunner) Ratio() uint {
 	return p.ratio
 }
@@ -114,7 +123,7 @@ func (p *PoseidonBuiltinRunner) GetAllocatedMemoryUnits(segments *memory.MemoryS
 
 	minStep := p.ratio * p.instancesPerComponent
 	if currentStep < minStep {
-		return 0, memory.InsufficientAllocatedCellsErrorMinStepNotReached(minStep, p.Name())
+		return 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_MIN_STEP, Builtin: p.Name(), Allocated: minStep}
 	}
 	value, err := utils.SafeDiv(currentStep, p.ratio)
 
@@ -137,7 +146,7 @@ func (p *PoseidonBuiltinRunner) GetUsedCellsAndAllocatedSizes(segments *memory.M
 	}
 
 	if used > size {
-		return 0, 0, memory.InsufficientAllocatedCellsErrorWithBuiltinName(p.Name(), used, size)
+		return 0, 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_BUILTIN, Builtin: p.Name(), Used: used, Allocated: size}
 	}
 
 	return used, size, nil
@@ -209,11 +218,25 @@ func (r *PoseidonBuiltinRunner) FinalStack(segments *memory.MemorySegmentManager
 	}
 }
 
+func (r *PoseidonBuiltinRunner) GetStopPtr() *uint {
+	return r.StopPtr
+}
+
 func (r *PoseidonBuiltinRunner) GetUsedInstances(segments *memory.MemorySegmentManager) (uint, error) {
 	usedCells, err := segments.GetSegmentUsedSize(uint(r.Base().SegmentIndex))
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
-	return utils.DivCeil(usedCells, r.CellsPerInstance()), nil
+	return InstancesFromCells(usedCells, r.CellsPerInstance()), nil
+}
+
+func (r *PoseidonBuiltinRunner) Clone() BuiltinRunner {
+	clone := *r
+	clone.StopPtr = cloneStopPtr(r.StopPtr)
+	clone.cache = make(map[memory.Relocatable]lambdaworks.Felt, len(r.cache))
+	for addr, value := range r.cache {
+		clone.cache[addr] = value
+	}
+	return &clone
 }
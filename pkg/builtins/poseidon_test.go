@@ -103,3 +103,18 @@ func TestPoseidonAddValidationRule(t *testing.T) {
 	}
 
 }
+
+func TestGetUsedInstancesPoseidon(t *testing.T) {
+	builtin := builtins.NewPoseidonBuiltinRunner(256)
+	segments := memory.NewMemorySegmentManager()
+	builtin.InitializeSegments(&segments)
+	segments.SegmentUsedSizes[0] = 7
+
+	usedInstances, err := builtin.GetUsedInstances(&segments)
+	if err != nil {
+		t.Errorf("GetUsedInstances failed with error: %s", err)
+	}
+	if usedInstances != 2 {
+		t.Errorf("Wrong used instances in %s builtin. Expected 2, got %d", builtin.Name(), usedInstances)
+	}
+}
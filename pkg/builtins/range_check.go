@@ -58,8 +58,13 @@ func (r *RangeCheckBuiltinRunner) SetBase(value memory.Relocatable) {
 	r.base = value
 }
 
-func (r *RangeCheckBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) {
-	r.base = segments.AddSegment()
+func (r *RangeCheckBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) error {
+	base, err := segments.AddSegment()
+	if err != nil {
+		return err
+	}
+	r.base = base
+	return nil
 }
 
 func (r *RangeCheckBuiltinRunner) InitialStack() []memory.MaybeRelocatable {
@@ -96,6 +101,10 @@ func (r *RangeCheckBuiltinRunner) Include(include bool) {
 	r.included = include
 }
 
+func (r *RangeCheckBuiltinRunner) IsIncluded() bool {
+	return r.included
+}
+
 func (r *RangeCheckBuiltinRunner) Ratio() uint {
 	return r.ratio
 }
@@ -122,7 +131,7 @@ func (r *RangeCheckBuiltinRunner) GetAllocatedMemoryUnits(segments *memory.Memor
 
 	minStep := r.Ratio() * r.instancesPerComponent
 	if currentStep < minStep {
-		return 0, memory.InsufficientAllocatedCellsErrorMinStepNotReached(minStep, r.Name())
+		return 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_MIN_STEP, Builtin: r.Name(), Allocated: minStep}
 	}
 	value, err := utils.SafeDiv(currentStep, r.Ratio())
 
@@ -149,7 +158,7 @@ func (r *RangeCheckBuiltinRunner) GetUsedCellsAndAllocatedSizes(segments *memory
 	}
 
 	if used > size {
-		return 0, 0, memory.InsufficientAllocatedCellsErrorWithBuiltinName(r.Name(), used, size)
+		return 0, 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_BUILTIN, Builtin: r.Name(), Used: used, Allocated: size}
 	}
 
 	return used, size, nil
@@ -266,11 +275,21 @@ func (r *RangeCheckBuiltinRunner) FinalStack(segments *memory.MemorySegmentManag
 	}
 }
 
+func (r *RangeCheckBuiltinRunner) GetStopPtr() *uint {
+	return r.StopPtr
+}
+
 func (r *RangeCheckBuiltinRunner) GetUsedInstances(segments *memory.MemorySegmentManager) (uint, error) {
 	usedCells, err := segments.GetSegmentUsedSize(uint(r.Base().SegmentIndex))
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
-	return usedCells, nil
+	return InstancesFromCells(usedCells, r.CellsPerInstance()), nil
+}
+
+func (r *RangeCheckBuiltinRunner) Clone() BuiltinRunner {
+	clone := *r
+	clone.StopPtr = cloneStopPtr(r.StopPtr)
+	return &clone
 }
@@ -148,3 +148,29 @@ func TestGetRangeCheckUsageEmptyMemory(t *testing.T) {
 		t.Errorf("rcMax should return nil, got %d", *resultMax)
 	}
 }
+
+func TestGetUsedInstancesRangeCheck(t *testing.T) {
+	builtin := builtins.NewRangeCheckBuiltinRunner(8)
+	segments := memory.NewMemorySegmentManager()
+	builtin.InitializeSegments(&segments)
+	segments.SegmentUsedSizes[0] = 5
+
+	usedInstances, err := builtin.GetUsedInstances(&segments)
+	if err != nil {
+		t.Errorf("GetUsedInstances failed with error: %s", err)
+	}
+	if usedInstances != 5 {
+		t.Errorf("Wrong used instances in %s builtin. Expected 5, got %d", builtin.Name(), usedInstances)
+	}
+}
+
+func TestGetUsedInstancesRangeCheckMissingSegmentUsedSize(t *testing.T) {
+	builtin := builtins.NewRangeCheckBuiltinRunner(8)
+	segments := memory.NewMemorySegmentManager()
+	builtin.InitializeSegments(&segments)
+
+	usedInstances, err := builtin.GetUsedInstances(&segments)
+	if err != nil || usedInstances != 0 {
+		t.Errorf("GetUsedInstances should return zero instances if the segment's used size is unknown, got %d, %v", usedInstances, err)
+	}
+}
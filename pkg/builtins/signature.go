@@ -34,8 +34,13 @@ func (signatureRunner *SignatureBuiltinRunner) Name() string {
 	return SIGNATURE_BUILTIN_NAME
 }
 
-func (signatureRunner *SignatureBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) {
-	signatureRunner.base = segments.AddSegment()
+func (signatureRunner *SignatureBuiltinRunner) InitializeSegments(segments *memory.MemorySegmentManager) error {
+	base, err := segments.AddSegment()
+	if err != nil {
+		return err
+	}
+	signatureRunner.base = base
+	return nil
 }
 
 func (signatureRunner *SignatureBuiltinRunner) InitialStack() []memory.MaybeRelocatable {
@@ -58,6 +63,10 @@ func (r *SignatureBuiltinRunner) Include(include bool) {
 	r.included = include
 }
 
+func (r *SignatureBuiltinRunner) IsIncluded() bool {
+	return r.included
+}
+
 func ValidationRuleSignature(mem *memory.Memory, address memory.Relocatable, signatureBuiltin *SignatureBuiltinRunner) ([]memory.Relocatable, error) {
 	cell_index := address.Offset % SIGNATURE_CELLS_PER_INSTANCE
 	var pub_key_address, message_addr memory.Relocatable
@@ -116,7 +125,7 @@ func (r *SignatureBuiltinRunner) GetAllocatedMemoryUnits(segments *memory.Memory
 
 	minStep := r.ratio * r.instancesPerComponent
 	if currentStep < minStep {
-		return 0, memory.InsufficientAllocatedCellsErrorMinStepNotReached(minStep, r.Name())
+		return 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_MIN_STEP, Builtin: r.Name(), Allocated: minStep}
 	}
 	value, err := utils.SafeDiv(currentStep, r.ratio)
 
@@ -147,7 +156,7 @@ func (r *SignatureBuiltinRunner) GetUsedCellsAndAllocatedSizes(segments *memory.
 	}
 
 	if used > size {
-		return 0, 0, memory.InsufficientAllocatedCellsErrorWithBuiltinName(r.Name(), used, size)
+		return 0, 0, memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_BUILTIN, Builtin: r.Name(), Used: used, Allocated: size}
 	}
 
 	return used, size, nil
@@ -228,11 +237,25 @@ func (r *SignatureBuiltinRunner) FinalStack(segments *memory.MemorySegmentManage
 	}
 }
 
+func (r *SignatureBuiltinRunner) GetStopPtr() *uint {
+	return r.StopPtr
+}
+
 func (r *SignatureBuiltinRunner) GetUsedInstances(segments *memory.MemorySegmentManager) (uint, error) {
 	usedCells, err := segments.GetSegmentUsedSize(uint(r.Base().SegmentIndex))
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
-	return utils.DivCeil(usedCells, r.CellsPerInstance()), nil
+	return InstancesFromCells(usedCells, r.CellsPerInstance()), nil
+}
+
+func (r *SignatureBuiltinRunner) Clone() BuiltinRunner {
+	clone := *r
+	clone.StopPtr = cloneStopPtr(r.StopPtr)
+	clone.signatures = make(map[memory.Relocatable]Signature, len(r.signatures))
+	for addr, signature := range r.signatures {
+		clone.signatures[addr] = signature
+	}
+	return &clone
 }
@@ -22,3 +22,18 @@ func TestInitializeSegmentsForSignatureBuiltin(t *testing.T) {
 		t.Errorf("Builtin %s base is not 0", range_check_builtin.Name())
 	}
 }
+
+func TestGetUsedInstancesSignature(t *testing.T) {
+	builtin := builtins.NewSignatureBuiltinRunner(2048)
+	segments := memory.NewMemorySegmentManager()
+	builtin.InitializeSegments(&segments)
+	segments.SegmentUsedSizes[0] = 5
+
+	usedInstances, err := builtin.GetUsedInstances(&segments)
+	if err != nil {
+		t.Errorf("GetUsedInstances failed with error: %s", err)
+	}
+	if usedInstances != 3 {
+		t.Errorf("Wrong used instances in %s builtin. Expected 3, got %d", builtin.Name(), usedInstances)
+	}
+}
@@ -31,7 +31,10 @@ func defaultDictNew(ids IdsManager, scopes *ExecutionScopes, vm *VirtualMachine)
 		dictManager = &newDictManager
 		scopes.AssignOrUpdateVariable("__dict_manager", dictManager)
 	}
-	base := dictManager.NewDefaultDictionary(defaultValue, vm)
+	base, err := dictManager.NewDefaultDictionary(defaultValue, vm)
+	if err != nil {
+		return err
+	}
 	return vm.Segments.Memory.Insert(vm.RunContext.Ap, memory.NewMaybeRelocatableRelocatable(base))
 }
 
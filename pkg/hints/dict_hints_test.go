@@ -90,7 +90,7 @@ func TestDictReadDefaultValue(t *testing.T) {
 	// Create dictManager with a default dictionary & add it to scope
 	dictManager := dict_manager.NewDictManager()
 	defaultValue := NewMaybeRelocatableFelt(FeltFromUint64(17))
-	dict_ptr := dictManager.NewDefaultDictionary(defaultValue, vm)
+	dict_ptr, _ := dictManager.NewDefaultDictionary(defaultValue, vm)
 	scopes.AssignOrUpdateVariable("__dict_manager", &dictManager)
 
 	idsManager := SetupIdsForTest(
@@ -127,7 +127,7 @@ func TestDictReadOk(t *testing.T) {
 	initialDict := map[MaybeRelocatable]MaybeRelocatable{
 		*NewMaybeRelocatableFelt(FeltOne()): *NewMaybeRelocatableFelt(FeltFromUint64(7)),
 	}
-	dict_ptr := dictManager.NewDictionary(&initialDict, vm)
+	dict_ptr, _ := dictManager.NewDictionary(&initialDict, vm)
 	scopes.AssignOrUpdateVariable("__dict_manager", &dictManager)
 
 	idsManager := SetupIdsForTest(
@@ -162,7 +162,7 @@ func TestDictReadNoVal(t *testing.T) {
 	// Create dictManager with a default dictionary & add it to scope
 	dictManager := dict_manager.NewDictManager()
 	initialDict := map[MaybeRelocatable]MaybeRelocatable{}
-	dict_ptr := dictManager.NewDictionary(&initialDict, vm)
+	dict_ptr, _ := dictManager.NewDictionary(&initialDict, vm)
 	scopes.AssignOrUpdateVariable("__dict_manager", &dictManager)
 
 	idsManager := SetupIdsForTest(
@@ -194,7 +194,7 @@ func TestDictWriteOk(t *testing.T) {
 	initialDict := map[MaybeRelocatable]MaybeRelocatable{
 		*NewMaybeRelocatableFelt(FeltOne()): *NewMaybeRelocatableFelt(FeltFromUint64(7)),
 	}
-	dict_ptr := dictManager.NewDictionary(&initialDict, vm)
+	dict_ptr, _ := dictManager.NewDictionary(&initialDict, vm)
 	scopes.AssignOrUpdateVariable("__dict_manager", &dictManager)
 
 	idsManager := SetupIdsForTest(
@@ -229,7 +229,7 @@ func TestDictWriteNoPrevValue(t *testing.T) {
 	// Create dictManager with a default dictionary & add it to scope
 	dictManager := dict_manager.NewDictManager()
 	initialDict := map[MaybeRelocatable]MaybeRelocatable{}
-	dict_ptr := dictManager.NewDictionary(&initialDict, vm)
+	dict_ptr, _ := dictManager.NewDictionary(&initialDict, vm)
 	scopes.AssignOrUpdateVariable("__dict_manager", &dictManager)
 
 	idsManager := SetupIdsForTest(
@@ -259,7 +259,7 @@ func TestDictWriteNewWriteDefault(t *testing.T) {
 	// Create dictManager with a default dictionary & add it to scope
 	dictManager := dict_manager.NewDictManager()
 	defaultValue := FeltFromUint64(17)
-	dict_ptr := dictManager.NewDefaultDictionary(NewMaybeRelocatableFelt(defaultValue), vm)
+	dict_ptr, _ := dictManager.NewDefaultDictionary(NewMaybeRelocatableFelt(defaultValue), vm)
 	scopes.AssignOrUpdateVariable("__dict_manager", &dictManager)
 
 	idsManager := SetupIdsForTest(
@@ -294,7 +294,7 @@ func TestDictUpdateDefaultValueOk(t *testing.T) {
 	// Create dictManager with a default dictionary & add it to scope
 	dictManager := dict_manager.NewDictManager()
 	defaultValue := FeltFromUint64(17)
-	dict_ptr := dictManager.NewDefaultDictionary(NewMaybeRelocatableFelt(defaultValue), vm)
+	dict_ptr, _ := dictManager.NewDefaultDictionary(NewMaybeRelocatableFelt(defaultValue), vm)
 	scopes.AssignOrUpdateVariable("__dict_manager", &dictManager)
 
 	idsManager := SetupIdsForTest(
@@ -325,7 +325,7 @@ func TestDictUpdateDefaultValueErr(t *testing.T) {
 	// Create dictManager with a default dictionary & add it to scope
 	dictManager := dict_manager.NewDictManager()
 	defaultValue := FeltFromUint64(17)
-	dict_ptr := dictManager.NewDefaultDictionary(NewMaybeRelocatableFelt(defaultValue), vm)
+	dict_ptr, _ := dictManager.NewDefaultDictionary(NewMaybeRelocatableFelt(defaultValue), vm)
 	scopes.AssignOrUpdateVariable("__dict_manager", &dictManager)
 
 	idsManager := SetupIdsForTest(
@@ -358,7 +358,7 @@ func TestDictUpdateOk(t *testing.T) {
 	initialDict := map[MaybeRelocatable]MaybeRelocatable{
 		*NewMaybeRelocatableFelt(FeltZero()): *NewMaybeRelocatableFelt(FeltOne()),
 	}
-	dict_ptr := dictManager.NewDictionary(&initialDict, vm)
+	dict_ptr, _ := dictManager.NewDictionary(&initialDict, vm)
 	scopes.AssignOrUpdateVariable("__dict_manager", &dictManager)
 
 	idsManager := SetupIdsForTest(
@@ -391,7 +391,7 @@ func TestDictUpdateErr(t *testing.T) {
 	initialDict := map[MaybeRelocatable]MaybeRelocatable{
 		*NewMaybeRelocatableFelt(FeltZero()): *NewMaybeRelocatableFelt(FeltOne()),
 	}
-	dict_ptr := dictManager.NewDictionary(&initialDict, vm)
+	dict_ptr, _ := dictManager.NewDictionary(&initialDict, vm)
 	scopes.AssignOrUpdateVariable("__dict_manager", &dictManager)
 
 	idsManager := SetupIdsForTest(
@@ -18,18 +18,24 @@ func NewDictManager() DictManager {
 	}
 }
 
-func (d *DictManager) NewDictionary(dict *map[MaybeRelocatable]MaybeRelocatable, vm *VirtualMachine) Relocatable {
-	base := vm.Segments.AddSegment()
+func (d *DictManager) NewDictionary(dict *map[MaybeRelocatable]MaybeRelocatable, vm *VirtualMachine) (Relocatable, error) {
+	base, err := vm.Segments.AddSegment()
+	if err != nil {
+		return Relocatable{}, err
+	}
 	newTracker := NewDictTrackerForDictionary(base, dict)
 	d.trackers[base.SegmentIndex] = &newTracker
-	return base
+	return base, nil
 }
 
-func (d *DictManager) NewDefaultDictionary(defaultValue *MaybeRelocatable, vm *VirtualMachine) Relocatable {
-	base := vm.Segments.AddSegment()
+func (d *DictManager) NewDefaultDictionary(defaultValue *MaybeRelocatable, vm *VirtualMachine) (Relocatable, error) {
+	base, err := vm.Segments.AddSegment()
+	if err != nil {
+		return Relocatable{}, err
+	}
 	newTracker := NewDictTrackerForDefaultDictionary(base, defaultValue)
 	d.trackers[base.SegmentIndex] = &newTracker
-	return base
+	return base, nil
 }
 
 func (d *DictManager) GetTracker(dict_ptr Relocatable) (*DictTracker, error) {
@@ -16,7 +16,7 @@ func TestDictManagerNewDictionaryGetTracker(t *testing.T) {
 	dictManager := NewDictManager()
 	initialDict := &map[MaybeRelocatable]MaybeRelocatable{}
 	vm := vm.NewVirtualMachine()
-	base := dictManager.NewDictionary(initialDict, vm)
+	base, _ := dictManager.NewDictionary(initialDict, vm)
 	if base.SegmentIndex != int(vm.Segments.Memory.NumSegments())-1 {
 		t.Errorf("Segment not created for DictTracker")
 	}
@@ -29,7 +29,7 @@ func TestDictManagerNewDictionaryGetTracker(t *testing.T) {
 func TestDictManagerNewDefaultDictionaryGetTracker(t *testing.T) {
 	dictManager := NewDictManager()
 	vm := vm.NewVirtualMachine()
-	base := dictManager.NewDefaultDictionary(nil, vm)
+	base, _ := dictManager.NewDefaultDictionary(nil, vm)
 	if base.SegmentIndex != int(vm.Segments.Memory.NumSegments())-1 {
 		t.Errorf("Segment not created for DictTracker")
 	}
@@ -43,7 +43,7 @@ func TestDictManagerNewDictionaryGetTrackerBadDictPtr(t *testing.T) {
 	dictManager := NewDictManager()
 	initialDict := &map[MaybeRelocatable]MaybeRelocatable{}
 	vm := vm.NewVirtualMachine()
-	base := dictManager.NewDictionary(initialDict, vm)
+	base, _ := dictManager.NewDictionary(initialDict, vm)
 	_, err := dictManager.GetTracker(base.AddUint(1))
 	if err == nil {
 		t.Errorf("GetTracker should have failed")
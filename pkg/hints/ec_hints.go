@@ -0,0 +1,73 @@
+package hints
+
+import (
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// Returns (value >> index) & 1
+func bitAt(value Felt, index uint) Felt {
+	return value.Shr(index).And(FeltOne())
+}
+
+// Fetches the scalars and the bit index used by the EC scalar multiplication bit extraction hints
+func getScalarsAndBitIndex(ids IdsManager, vm *VirtualMachine) (Felt, Felt, uint, error) {
+	scalar_u, err := ids.GetFelt("scalar_u", vm)
+	if err != nil {
+		return Felt{}, Felt{}, 0, err
+	}
+	scalar_v, err := ids.GetFelt("scalar_v", vm)
+	if err != nil {
+		return Felt{}, Felt{}, 0, err
+	}
+	mFelt, err := ids.GetFelt("m", vm)
+	if err != nil {
+		return Felt{}, Felt{}, 0, err
+	}
+	m, err := mFelt.ToU64()
+	if err != nil {
+		return Felt{}, Felt{}, 0, err
+	}
+	return scalar_u, scalar_v, uint(m), nil
+}
+
+// Implements hint:from starkware.cairo.common.ec.cairo
+//
+//	%{ ids.dibit = ((ids.scalar_u >> ids.m) & 1) + 2 * ((ids.scalar_v >> ids.m) & 1) %}
+func di_bit(ids IdsManager, vm *VirtualMachine) error {
+	scalar_u, scalar_v, m, err := getScalarsAndBitIndex(ids, vm)
+	if err != nil {
+		return err
+	}
+	dibit := bitAt(scalar_u, m).Add(FeltFromUint64(2).Mul(bitAt(scalar_v, m)))
+	return ids.Insert("dibit", NewMaybeRelocatableFelt(dibit), vm)
+}
+
+// Implements hint:from starkware.cairo.common.ec.cairo
+//
+//	%{
+//	    ids.quad_bit = (
+//	        8 * ((ids.scalar_v >> ids.m) & 1)
+//	        + 4 * ((ids.scalar_u >> ids.m) & 1)
+//	        + 2 * ((ids.scalar_v >> (ids.m - 1)) & 1)
+//	        + ((ids.scalar_u >> (ids.m - 1)) & 1)
+//	    )
+//
+// %}
+func quad_bit(ids IdsManager, vm *VirtualMachine) error {
+	scalar_u, scalar_v, m, err := getScalarsAndBitIndex(ids, vm)
+	if err != nil {
+		return err
+	}
+	if m == 0 {
+		return errors.New("quad_bit failed: ids.m must be at least 1")
+	}
+	quad_bit := FeltFromUint64(8).Mul(bitAt(scalar_v, m)).
+		Add(FeltFromUint64(4).Mul(bitAt(scalar_u, m))).
+		Add(FeltFromUint64(2).Mul(bitAt(scalar_v, m-1))).
+		Add(bitAt(scalar_u, m-1))
+	return ids.Insert("quad_bit", NewMaybeRelocatableFelt(quad_bit), vm)
+}
@@ -0,0 +1,95 @@
+package hints_test
+
+import (
+	"testing"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func TestDiBitHintBitThree(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			// 0b1000: bit 3 is set
+			"scalar_u": {NewMaybeRelocatableFelt(FeltFromUint64(8))},
+			// 0b10111: bit 3 is not set
+			"scalar_v": {NewMaybeRelocatableFelt(FeltFromUint64(23))},
+			"m":        {NewMaybeRelocatableFelt(FeltFromUint64(3))},
+			"dibit":    {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: DI_BIT,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err != nil {
+		t.Errorf("DI_BIT hint test failed with error %s", err)
+		return
+	}
+	dibit, err := idsManager.GetFelt("dibit", vm)
+	if err != nil || dibit != FeltOne() {
+		t.Errorf("DI_BIT hint test incorrect value for ids.dibit")
+	}
+}
+
+func TestQuadBitHintBitThree(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			// 0b0100: bit 3 is not set, bit 2 is set
+			"scalar_u": {NewMaybeRelocatableFelt(FeltFromUint64(4))},
+			// 0b1000: bit 3 is set, bit 2 is not set
+			"scalar_v": {NewMaybeRelocatableFelt(FeltFromUint64(8))},
+			"m":        {NewMaybeRelocatableFelt(FeltFromUint64(3))},
+			"quad_bit": {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: QUAD_BIT,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err != nil {
+		t.Errorf("QUAD_BIT hint test failed with error %s", err)
+		return
+	}
+	// 8 * 1 + 4 * 0 + 2 * 0 + 1
+	quad_bit, err := idsManager.GetFelt("quad_bit", vm)
+	if err != nil || quad_bit != FeltFromUint64(9) {
+		t.Errorf("QUAD_BIT hint test incorrect value for ids.quad_bit")
+	}
+}
+
+func TestQuadBitHintZeroIndex(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"scalar_u": {NewMaybeRelocatableFelt(FeltFromUint64(4))},
+			"scalar_v": {NewMaybeRelocatableFelt(FeltFromUint64(8))},
+			"m":        {NewMaybeRelocatableFelt(FeltZero())},
+			"quad_bit": {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: QUAD_BIT,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err == nil {
+		t.Errorf("QUAD_BIT hint should have failed")
+	}
+}
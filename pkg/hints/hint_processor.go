@@ -16,6 +16,10 @@ type HintData struct {
 	Code string
 }
 
+func (h HintData) HintCode() string {
+	return h.Code
+}
+
 type CairoVmHintProcessor struct {
 }
 
@@ -59,11 +63,39 @@ func (p *CairoVmHintProcessor) ExecuteHint(vm *vm.VirtualMachine, hintData *any,
 		return vm_exit_scope(execScopes)
 	case ASSERT_NOT_EQUAL:
 		return assert_not_equal(data.Ids, vm)
+	case IS_QUAD_RESIDUE:
+		return is_quad_residue(data.Ids, vm)
+	case ASSERT_250_BITS:
+		return assert_250_bit(data.Ids, vm)
+	case ASSERT_LE_FELT_V_0_6:
+		return assert_le_felt_v_0_6(data.Ids, vm)
+	case ASSERT_LE_FELT_V_0_8:
+		return assert_le_felt_v_0_8(data.Ids, vm)
+	case DI_BIT:
+		return di_bit(data.Ids, vm)
+	case QUAD_BIT:
+		return quad_bit(data.Ids, vm)
+	case IMPORT_SECP256R1_P:
+		return import_secp256r1_p(execScopes)
+	case IMPORT_SECP256R1_N:
+		return import_secp256r1_n(execScopes)
+	case SECP256R1_REDUCE:
+		return secp256r1_reduce(data.Ids, vm, execScopes)
+	case DIV_MOD_N_PACKED_DIVMOD_EXTERNAL_N:
+		return div_mod_n_packed_external_n(data.Ids, vm, execScopes)
 	case MEMCPY_ENTER_SCOPE:
 		return memcpy_enter_scope(data.Ids, vm, execScopes)
 	case VM_ENTER_SCOPE:
 		return vm_enter_scope(execScopes)
+	case UNSAFE_KECCAK:
+		return unsafe_keccak(data.Ids, vm, execScopes)
 	default:
+		if name, ok := matchIdsAddSegment(data.Code); ok {
+			return ids_add_segment(name, data.Ids, vm)
+		}
+		if assignments, ok := matchVmEnterScopeWithVariables(data.Code); ok {
+			return vm_enter_scope_with_variables(assignments, execScopes)
+		}
 		return errors.Errorf("Unknown Hint: %s", data.Code)
 	}
 }
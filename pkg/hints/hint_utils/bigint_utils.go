@@ -0,0 +1,51 @@
+package hint_utils
+
+import (
+	"math/big"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+)
+
+// Number of bits in each limb of a BigInt3
+const BASE_86 = 86
+
+// Representation of the cairo BigInt3 struct (d0, d1, d2), used by the secp hints
+type BigInt3 struct {
+	Limbs [3]lambdaworks.Felt
+}
+
+// Fetches the BigInt3 struct ids.<name> from memory
+func BigInt3FromVarName(name string, ids IdsManager, vm *VirtualMachine) (BigInt3, error) {
+	var bigint BigInt3
+	for i := 0; i < 3; i++ {
+		limb, err := ids.GetStructFieldFelt(name, uint(i), vm)
+		if err != nil {
+			return BigInt3{}, err
+		}
+		bigint.Limbs[i] = limb
+	}
+	return bigint, nil
+}
+
+// Returns d0 + d1 * 2**86 + d2 * 2**172, reading each limb as a signed value, like secp_utils.pack
+func (b BigInt3) Pack() *big.Int {
+	result := new(big.Int)
+	for i := 2; i >= 0; i-- {
+		result.Lsh(result, BASE_86)
+		result.Add(result, b.Limbs[i].ToSigned())
+	}
+	return result
+}
+
+// Returns the prime of the secp256r1 (P-256) curve's field
+func Secp256r1P() *big.Int {
+	p, _ := new(big.Int).SetString("ffffffff00000001000000000000000000000000ffffffffffffffffffffffff", 16)
+	return p
+}
+
+// Returns the order of the secp256r1 (P-256) curve
+func Secp256r1N() *big.Int {
+	n, _ := new(big.Int).SetString("ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551", 16)
+	return n
+}
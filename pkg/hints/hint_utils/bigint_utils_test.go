@@ -0,0 +1,28 @@
+package hint_utils_test
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+)
+
+func TestBigInt3Pack(t *testing.T) {
+	bigint := BigInt3{Limbs: [3]Felt{FeltFromUint64(1), FeltFromUint64(2), FeltFromUint64(3)}}
+	expected := new(big.Int).Lsh(big.NewInt(3), 172)
+	expected.Add(expected, new(big.Int).Lsh(big.NewInt(2), 86))
+	expected.Add(expected, big.NewInt(1))
+	if bigint.Pack().Cmp(expected) != 0 {
+		t.Errorf("Wrong packed value. Expected %s, got %s", expected, bigint.Pack())
+	}
+}
+
+func TestBigInt3PackNegativeLimb(t *testing.T) {
+	// d0 = -1, d1 = 1: 2**86 - 1
+	bigint := BigInt3{Limbs: [3]Felt{FeltZero().Sub(FeltOne()), FeltOne(), FeltZero()}}
+	expected := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 86), big.NewInt(1))
+	if bigint.Pack().Cmp(expected) != 0 {
+		t.Errorf("Wrong packed value. Expected %s, got %s", expected, bigint.Pack())
+	}
+}
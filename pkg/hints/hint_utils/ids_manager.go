@@ -207,6 +207,17 @@ func getStructFieldFromReference(reference *HintReference, field_off uint, apTra
 	return nil, false
 }
 
+// Computes the address indicated by a reference, applying the ap tracking correction between the reference's
+// ap tracking data and the given one (usually the hint's) when the reference is ap-based.
+// This is the same computation IdsManager performs to resolve ids, exposed for custom hint implementations
+func ComputeAddrFromReference(reference HintReference, apTracking parser.ApTrackingData, vm *VirtualMachine) (Relocatable, error) {
+	addr, ok := getAddressFromReference(&reference, apTracking, vm)
+	if !ok {
+		return Relocatable{}, ErrIdsManager(errors.New("Failed to compute address from reference"))
+	}
+	return addr, nil
+}
+
 // Returns the addr indicated by the reference
 func getAddressFromReference(reference *HintReference, apTracking parser.ApTrackingData, vm *VirtualMachine) (Relocatable, bool) {
 	if reference.Offset1.ValueType != Reference {
@@ -285,3 +285,41 @@ func TestIdsManagerGetStructFieldTest(t *testing.T) {
 		t.Errorf("IdsManager.GetStructFieldFelt returned wrong values")
 	}
 }
+
+func TestComputeAddrFromReferenceWithApTrackingCorrection(t *testing.T) {
+	reference := HintReference{
+		Offset1: OffsetValue{
+			Register:  vm.AP,
+			ValueType: Reference,
+			Value:     -1,
+		},
+		ApTrackingData: parser.ApTrackingData{Group: 2, Offset: 1},
+	}
+	// Ap tracking correction ap - (hintOff - refOff) + offset = (1, 7) - (4 - 1) - 1 = (1, 3)
+	vm := vm.NewVirtualMachine()
+	vm.RunContext.Ap = memory.NewRelocatable(1, 7)
+	addr, err := ComputeAddrFromReference(reference, parser.ApTrackingData{Group: 2, Offset: 4}, vm)
+	if err != nil {
+		t.Errorf("Error in test: %s", err)
+	}
+	expected := memory.NewRelocatable(1, 3)
+	if addr != expected {
+		t.Errorf("ComputeAddrFromReference returned wrong value. Expected %v, got %v", expected, addr)
+	}
+}
+
+func TestComputeAddrFromReferenceDifferentApTrackingGroup(t *testing.T) {
+	reference := HintReference{
+		Offset1: OffsetValue{
+			Register:  vm.AP,
+			ValueType: Reference,
+		},
+		ApTrackingData: parser.ApTrackingData{Group: 1, Offset: 1},
+	}
+	vm := vm.NewVirtualMachine()
+	vm.RunContext.Ap = memory.NewRelocatable(1, 7)
+	_, err := ComputeAddrFromReference(reference, parser.ApTrackingData{Group: 2, Offset: 4}, vm)
+	if err == nil {
+		t.Errorf("ComputeAddrFromReference should have failed")
+	}
+}
@@ -0,0 +1,89 @@
+package hints
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+// Amount of bytes held by each word of the keccak input
+const KECCAK_WORD_BYTES = 16
+
+// Implements hint:
+//
+//	%{
+//	    from eth_hash.auto import keccak
+//
+//	    data, length = ids.data, ids.length
+//
+//	    if '__keccak_max_size' in globals():
+//	        assert length <= __keccak_max_size, \
+//	            f'unsafe_keccak() can only be used with length<={__keccak_max_size}. ' \
+//	            f'Got: length={length}.'
+//
+//	    keccak_input = bytearray()
+//	    for word_i, byte_i in enumerate(range(0, length, 16)):
+//	        word = memory[data + word_i]
+//	        n_bytes = min(16, length - byte_i)
+//	        assert 0 <= word < 2 ** (8 * n_bytes)
+//	        keccak_input += word.to_bytes(n_bytes, 'big')
+//
+//	    hashed = keccak(keccak_input)
+//	    ids.high = int.from_bytes(hashed[:16], 'big')
+//	    ids.low = int.from_bytes(hashed[16:32], 'big')
+//
+// %}
+func unsafe_keccak(ids IdsManager, vm *VirtualMachine, execScopes *types.ExecutionScopes) error {
+	lengthFelt, err := ids.GetFelt("length", vm)
+	if err != nil {
+		return err
+	}
+	if maxSize, err := execScopes.Get("__keccak_max_size"); err == nil {
+		maxSizeFelt, ok := maxSize.(Felt)
+		if !ok {
+			return errors.New("__keccak_max_size is not a Felt")
+		}
+		if lengthFelt.Cmp(maxSizeFelt) > 0 {
+			return errors.Errorf("unsafe_keccak() can only be used with length<=%s. Got: length=%s.", maxSizeFelt.ToHexString(), lengthFelt.ToHexString())
+		}
+	}
+	length, err := lengthFelt.ToU64()
+	if err != nil {
+		return err
+	}
+	data, err := ids.GetRelocatable("data", vm)
+	if err != nil {
+		return err
+	}
+
+	keccakInput := make([]byte, 0, length)
+	for wordIdx, byteIdx := uint(0), uint64(0); byteIdx < length; wordIdx, byteIdx = wordIdx+1, byteIdx+KECCAK_WORD_BYTES {
+		word, err := vm.Segments.Memory.GetFelt(data.AddUint(wordIdx))
+		if err != nil {
+			return err
+		}
+		nBytes := length - byteIdx
+		if nBytes > KECCAK_WORD_BYTES {
+			nBytes = KECCAK_WORD_BYTES
+		}
+		if uint64(word.Bits()) > 8*nBytes {
+			return errors.Errorf("Invalid word size: %s, expected a value of at most %d bytes", word.ToHexString(), nBytes)
+		}
+		wordBytes := word.ToBeBytes()
+		keccakInput = append(keccakInput, wordBytes[32-nBytes:]...)
+	}
+
+	hashed := builtins.Keccak256(keccakInput)
+	var highBytes, lowBytes [32]byte
+	copy(highBytes[16:], hashed[:16])
+	copy(lowBytes[16:], hashed[16:])
+	err = ids.Insert("high", NewMaybeRelocatableFelt(FeltFromBeBytes(&highBytes)), vm)
+	if err != nil {
+		return err
+	}
+	return ids.Insert("low", NewMaybeRelocatableFelt(FeltFromBeBytes(&lowBytes)), vm)
+}
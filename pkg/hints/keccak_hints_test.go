@@ -0,0 +1,98 @@
+package hints_test
+
+import (
+	"testing"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/types"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func TestUnsafeKeccakAbc(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	data, _ := vm.Segments.AddSegment()
+	// "abc" as a single big endian word
+	vm.Segments.Memory.Insert(data, NewMaybeRelocatableFelt(FeltFromHex("0x616263")))
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"data":   {NewMaybeRelocatableRelocatable(data)},
+			"length": {NewMaybeRelocatableFelt(FeltFromUint64(3))},
+			"high":   {nil},
+			"low":    {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: UNSAFE_KECCAK,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, NewExecutionScopes())
+	if err != nil {
+		t.Errorf("UNSAFE_KECCAK hint test failed with error %s", err)
+	}
+	// keccak256("abc") = 0x4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45
+	high, err := idsManager.GetFelt("high", vm)
+	if err != nil || high != FeltFromHex("0x4e03657aea45a94fc7d47ba826c8d667") {
+		t.Errorf("Wrong ids.high value: %s", high.ToHexString())
+	}
+	low, err := idsManager.GetFelt("low", vm)
+	if err != nil || low != FeltFromHex("0xc0d1e6e33a64a036ec44f58fa12d6c45") {
+		t.Errorf("Wrong ids.low value: %s", low.ToHexString())
+	}
+}
+
+func TestUnsafeKeccakWordTooBig(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	data, _ := vm.Segments.AddSegment()
+	vm.Segments.Memory.Insert(data, NewMaybeRelocatableFelt(FeltFromHex("0x61626364")))
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"data":   {NewMaybeRelocatableRelocatable(data)},
+			"length": {NewMaybeRelocatableFelt(FeltFromUint64(3))},
+			"high":   {nil},
+			"low":    {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: UNSAFE_KECCAK,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, NewExecutionScopes())
+	if err == nil {
+		t.Errorf("UNSAFE_KECCAK hint should have failed with a word larger than the remaining length")
+	}
+}
+
+func TestUnsafeKeccakLengthOverMaxSize(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	data, _ := vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"data":   {NewMaybeRelocatableRelocatable(data)},
+			"length": {NewMaybeRelocatableFelt(FeltFromUint64(20))},
+			"high":   {nil},
+			"low":    {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: UNSAFE_KECCAK,
+	})
+	scopes := NewExecutionScopes()
+	scopes.AssignOrUpdateVariable("__keccak_max_size", FeltFromUint64(10))
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err == nil {
+		t.Errorf("UNSAFE_KECCAK hint should have failed with a length over __keccak_max_size")
+	}
+}
@@ -89,3 +89,124 @@ func assert_not_equal(ids IdsManager, vm *VirtualMachine) error {
 	}
 	return nil
 }
+
+// Implements hint:from starkware.cairo.common.math.cairo
+//
+//	%{
+//	    from starkware.crypto.signature.signature import FIELD_PRIME
+//	    from starkware.python.math_utils import div_mod, is_quad_residue, sqrt
+//
+//	    x = ids.x
+//	    if is_quad_residue(x, FIELD_PRIME):
+//	        ids.y = sqrt(x, FIELD_PRIME)
+//	    else:
+//	        ids.y = sqrt(div_mod(x, 3, FIELD_PRIME), FIELD_PRIME)
+//
+// %}
+func is_quad_residue(ids IdsManager, vm *VirtualMachine) error {
+	x, err := ids.GetFelt("x", vm)
+	if err != nil {
+		return err
+	}
+	if !x.IsQuadraticResidue() {
+		// 3 is not a quadratic residue, so x / 3 is guaranteed to be one
+		x = x.Div(FeltFromUint64(3))
+	}
+	y, ok := x.Sqrt()
+	if !ok {
+		return errors.Errorf("is_quad_residue failed: no square root found for %s", x.ToHexString())
+	}
+	return ids.Insert("y", NewMaybeRelocatableFelt(y), vm)
+}
+
+// Implements hint:from starkware.cairo.common.math.cairo
+//
+//	%{
+//	    from starkware.cairo.common.math_utils import as_int
+//
+//	    # Correctness check.
+//	    value = as_int(ids.value, PRIME) % PRIME
+//	    assert value < ids.UPPER_BOUND, f'{value} is outside of the range [0, 2**250).'
+//
+//	    # Calculation for the assertion.
+//	    ids.high, ids.low = divmod(ids.value, ids.SHIFT)
+//
+// %}
+func assert_250_bit(ids IdsManager, vm *VirtualMachine) error {
+	// The UPPER_BOUND and SHIFT constants of assert_250_bit
+	upperBound := FeltOne().Shl(250)
+	shift := FeltOne().Shl(128)
+
+	value, err := ids.GetFelt("value", vm)
+	if err != nil {
+		return err
+	}
+	if value.Cmp(upperBound) >= 0 {
+		return errors.Errorf("Assertion failed, %s is outside of the range [0, 2**250)", value.ToHexString())
+	}
+	high, low := value.DivRem(shift)
+	err = ids.Insert("high", NewMaybeRelocatableFelt(high), vm)
+	if err != nil {
+		return err
+	}
+	return ids.Insert("low", NewMaybeRelocatableFelt(low), vm)
+}
+
+// Implements hint:from starkware.cairo.common.math.cairo, as emitted by cairo-lang v0.6
+//
+//	%{
+//	    from starkware.cairo.common.math_utils import assert_integer
+//	    assert_integer(ids.a)
+//	    assert_integer(ids.b)
+//	    assert (ids.a % PRIME) <= (ids.b % PRIME), \
+//	        f'a = {ids.a % PRIME} is not less than or equal to b = {ids.b % PRIME}.'
+//
+// %}
+func assert_le_felt_v_0_6(ids IdsManager, vm *VirtualMachine) error {
+	a, err := ids.GetFelt("a", vm)
+	if err != nil {
+		return err
+	}
+	b, err := ids.GetFelt("b", vm)
+	if err != nil {
+		return err
+	}
+	if a.Cmp(b) > 0 {
+		return errors.Errorf("Assertion failed, a = %s is not less than or equal to b = %s", a.ToHexString(), b.ToHexString())
+	}
+	return nil
+}
+
+// Implements hint:from starkware.cairo.common.math.cairo, as emitted by cairo-lang v0.8
+//
+//	%{
+//	    from starkware.cairo.common.math_utils import assert_integer
+//	    assert_integer(ids.a)
+//	    assert_integer(ids.b)
+//	    a = ids.a % PRIME
+//	    b = ids.b % PRIME
+//	    assert a <= b, f'a = {a} is not less than or equal to b = {b}.'
+//
+//	    ids.small_inputs = int(
+//	        a < range_check_builtin.bound and (b - a) < range_check_builtin.bound)
+//
+// %}
+func assert_le_felt_v_0_8(ids IdsManager, vm *VirtualMachine) error {
+	a, err := ids.GetFelt("a", vm)
+	if err != nil {
+		return err
+	}
+	b, err := ids.GetFelt("b", vm)
+	if err != nil {
+		return err
+	}
+	if a.Cmp(b) > 0 {
+		return errors.Errorf("Assertion failed, a = %s is not less than or equal to b = %s", a.ToHexString(), b.ToHexString())
+	}
+	rcBoundBits := Limb(builtins.RANGE_CHECK_N_PARTS * builtins.INNER_RC_BOUND_SHIFT)
+	small_inputs := uint64(0)
+	if a.Bits() <= rcBoundBits && b.Sub(a).Bits() <= rcBoundBits {
+		small_inputs = 1
+	}
+	return ids.Insert("small_inputs", NewMaybeRelocatableFelt(FeltFromUint64(small_inputs)), vm)
+}
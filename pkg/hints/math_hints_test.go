@@ -1,6 +1,7 @@
 package hints_test
 
 import (
+	"errors"
 	"testing"
 
 	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
@@ -287,3 +288,303 @@ func TestAssertNotEqualHintOkRelocatables(t *testing.T) {
 		t.Errorf("ASSERT_NOT_EQUAL hint failed with error: %s", err)
 	}
 }
+
+func TestIsQuadResidueHintResidue(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"x": {NewMaybeRelocatableFelt(FeltFromUint64(4))},
+			"y": {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: IS_QUAD_RESIDUE,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err != nil {
+		t.Errorf("IS_QUAD_RESIDUE hint failed with error: %s", err)
+	}
+	y, err := idsManager.GetFelt("y", vm)
+	if err != nil || y != FeltFromUint64(2) {
+		t.Errorf("IS_QUAD_RESIDUE hint test incorrect value for ids.y, expected 2, got %s", y.ToHexString())
+	}
+}
+
+func TestIsQuadResidueHintNonResidue(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"x": {NewMaybeRelocatableFelt(FeltFromUint64(12))},
+			"y": {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: IS_QUAD_RESIDUE,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err != nil {
+		t.Errorf("IS_QUAD_RESIDUE hint failed with error: %s", err)
+	}
+	// 12 is not a quadratic residue, so y = sqrt(12 / 3) = 2
+	y, err := idsManager.GetFelt("y", vm)
+	if err != nil || y != FeltFromUint64(2) {
+		t.Errorf("IS_QUAD_RESIDUE hint test incorrect value for ids.y, expected 2, got %s", y.ToHexString())
+	}
+}
+
+func TestIsQuadResidueHintZero(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"x": {NewMaybeRelocatableFelt(FeltZero())},
+			"y": {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: IS_QUAD_RESIDUE,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err != nil {
+		t.Errorf("IS_QUAD_RESIDUE hint failed with error: %s", err)
+	}
+	y, err := idsManager.GetFelt("y", vm)
+	if err != nil || !y.IsZero() {
+		t.Errorf("IS_QUAD_RESIDUE hint test incorrect value for ids.y, expected 0, got %s", y.ToHexString())
+	}
+}
+
+func TestAssertNotZeroHintFailStepReturnsHintError(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"value": {NewMaybeRelocatableFelt(FeltFromUint64(0))},
+		},
+		vm,
+	)
+	hintProcessor := &CairoVmHintProcessor{}
+	hintDataMap := map[uint][]any{
+		0: {HintData{Ids: idsManager, Code: ASSERT_NOT_ZERO}},
+	}
+	constants := make(map[string]Felt)
+	err := vm.Step(hintProcessor, &hintDataMap, &constants, nil)
+	var hintError *HintError
+	if !errors.As(err, &hintError) {
+		t.Errorf("Step should have failed with a HintError, got: %v", err)
+		return
+	}
+	if hintError.Code != ASSERT_NOT_ZERO {
+		t.Errorf("HintError has wrong code. Expected %s, got %s", ASSERT_NOT_ZERO, hintError.Code)
+	}
+	if hintError.Pc != NewRelocatable(0, 0) {
+		t.Errorf("HintError has wrong pc. Expected (0, 0), got %v", hintError.Pc)
+	}
+}
+
+func TestAssert250BitHintOk(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	// 2**250 - 1
+	value := FeltOne().Shl(250).Sub(FeltOne())
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"value": {NewMaybeRelocatableFelt(value)},
+			"high":  {nil},
+			"low":   {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: ASSERT_250_BITS,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err != nil {
+		t.Errorf("ASSERT_250_BITS hint test failed with error %s", err)
+		return
+	}
+	// Check ids.high and ids.low
+	high, err := idsManager.GetFelt("high", vm)
+	if err != nil || high != FeltOne().Shl(122).Sub(FeltOne()) {
+		t.Errorf("ASSERT_250_BITS hint test incorrect value for ids.high")
+	}
+	low, err := idsManager.GetFelt("low", vm)
+	if err != nil || low != FeltOne().Shl(128).Sub(FeltOne()) {
+		t.Errorf("ASSERT_250_BITS hint test incorrect value for ids.low")
+	}
+}
+
+func TestAssert250BitHintFail(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	// 2**250
+	value := FeltOne().Shl(250)
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"value": {NewMaybeRelocatableFelt(value)},
+			"high":  {nil},
+			"low":   {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: ASSERT_250_BITS,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err == nil {
+		t.Errorf("ASSERT_250_BITS hint should have failed")
+	}
+}
+
+func TestAssertLeFeltV06Ok(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"a": {NewMaybeRelocatableFelt(FeltFromUint64(17))},
+			"b": {NewMaybeRelocatableFelt(FeltFromUint64(17))},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: ASSERT_LE_FELT_V_0_6,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err != nil {
+		t.Errorf("ASSERT_LE_FELT_V_0_6 hint test failed with error %s", err)
+	}
+}
+
+func TestAssertLeFeltV06Fail(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"a": {NewMaybeRelocatableFelt(FeltFromDecString("-1"))},
+			"b": {NewMaybeRelocatableFelt(FeltFromUint64(17))},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: ASSERT_LE_FELT_V_0_6,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err == nil {
+		t.Errorf("ASSERT_LE_FELT_V_0_6 hint should have failed")
+	}
+}
+
+func TestAssertLeFeltV08SmallInputs(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"a":            {NewMaybeRelocatableFelt(FeltFromUint64(3))},
+			"b":            {NewMaybeRelocatableFelt(FeltFromUint64(17))},
+			"small_inputs": {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: ASSERT_LE_FELT_V_0_8,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err != nil {
+		t.Errorf("ASSERT_LE_FELT_V_0_8 hint test failed with error %s", err)
+		return
+	}
+	small_inputs, err := idsManager.GetFelt("small_inputs", vm)
+	if err != nil || small_inputs != FeltOne() {
+		t.Errorf("ASSERT_LE_FELT_V_0_8 hint test incorrect value for ids.small_inputs")
+	}
+}
+
+func TestAssertLeFeltV08BigInputs(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"a":            {NewMaybeRelocatableFelt(FeltFromUint64(3))},
+			"b":            {NewMaybeRelocatableFelt(FeltOne().Shl(200))},
+			"small_inputs": {nil},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: ASSERT_LE_FELT_V_0_8,
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err != nil {
+		t.Errorf("ASSERT_LE_FELT_V_0_8 hint test failed with error %s", err)
+		return
+	}
+	small_inputs, err := idsManager.GetFelt("small_inputs", vm)
+	if err != nil || !small_inputs.IsZero() {
+		t.Errorf("ASSERT_LE_FELT_V_0_8 hint test incorrect value for ids.small_inputs")
+	}
+}
+
+func TestAssertLeFeltV08SmallInputsBounds(t *testing.T) {
+	bound := FeltOne().Shl(128)
+	cases := []struct {
+		a, b        Felt
+		smallInputs Felt
+	}{
+		{FeltOne().Shl(127), FeltOne().Shl(127), FeltOne()},
+		{FeltOne().Shl(127), bound.Sub(FeltOne()), FeltOne()},
+		{bound.Sub(FeltOne()), bound.Sub(FeltOne()), FeltOne()},
+		{FeltZero(), bound.Sub(FeltOne()), FeltOne()},
+		{bound, bound, FeltZero()},
+		{FeltZero(), bound, FeltZero()},
+	}
+	for _, c := range cases {
+		vm := NewVirtualMachine()
+		vm.Segments.AddSegment()
+		idsManager := SetupIdsForTest(
+			map[string][]*MaybeRelocatable{
+				"a":            {NewMaybeRelocatableFelt(c.a)},
+				"b":            {NewMaybeRelocatableFelt(c.b)},
+				"small_inputs": {nil},
+			},
+			vm,
+		)
+		hintProcessor := CairoVmHintProcessor{}
+		hintData := any(HintData{
+			Ids:  idsManager,
+			Code: ASSERT_LE_FELT_V_0_8,
+		})
+		err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+		if err != nil {
+			t.Errorf("ASSERT_LE_FELT_V_0_8 hint test failed with error %s", err)
+			return
+		}
+		small_inputs, err := idsManager.GetFelt("small_inputs", vm)
+		if err != nil || small_inputs != c.smallInputs {
+			t.Errorf("ASSERT_LE_FELT_V_0_8 hint test incorrect value for ids.small_inputs with a = %s, b = %s: expected %s, got %s",
+				c.a.ToHexString(), c.b.ToHexString(), c.smallInputs.ToHexString(), small_inputs.ToHexString())
+		}
+	}
+}
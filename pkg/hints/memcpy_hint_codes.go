@@ -1,6 +1,12 @@
 package hints
 
 const ADD_SEGMENT = "memory[ap] = segments.add()"
+
+// Matches the `ids.<name> = segments.add()` hints, which can allocate a segment for any ids variable
+const IDS_ADD_SEGMENT_PATTERN = `^ids\.([A-Za-z_][A-Za-z0-9_]*) = segments\.add\(\)$`
 const VM_EXIT_SCOPE = "vm_exit_scope()"
 const VM_ENTER_SCOPE = "vm_enter_scope()"
+
+// Matches the `vm_enter_scope(dict(a=b, ...))` hints, which copy variables of the current scope into the new one
+const VM_ENTER_SCOPE_WITH_VARIABLES_PATTERN = `^vm_enter_scope\(dict\((\w+=\w+(?:, \w+=\w+)*)\)\)$`
 const MEMCPY_ENTER_SCOPE = "vm_enter_scope({'n': ids.len})"
@@ -1,18 +1,45 @@
 package hints
 
 import (
+	"regexp"
+	"strings"
+
 	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
 	"github.com/lambdaclass/cairo-vm.go/pkg/types"
 	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
 	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
 )
 
 // Implements hint: memory[ap] = segments.add()
 func add_segment(vm *VirtualMachine) error {
-	new_segment_base := vm.Segments.AddSegment()
+	new_segment_base, err := vm.Segments.AddSegment()
+	if err != nil {
+		return err
+	}
 	return vm.Segments.Memory.Insert(vm.RunContext.Ap, NewMaybeRelocatableRelocatable(new_segment_base))
 }
 
+var idsAddSegmentRegexp = regexp.MustCompile(IDS_ADD_SEGMENT_PATTERN)
+
+// Returns the name of the ids variable if the hint code has the form `ids.<name> = segments.add()`
+func matchIdsAddSegment(code string) (string, bool) {
+	match := idsAddSegmentRegexp.FindStringSubmatch(code)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// Implements hint: ids.<name> = segments.add()
+func ids_add_segment(name string, ids IdsManager, vm *VirtualMachine) error {
+	new_segment_base, err := vm.Segments.AddSegment()
+	if err != nil {
+		return err
+	}
+	return ids.Insert(name, NewMaybeRelocatableRelocatable(new_segment_base), vm)
+}
+
 // Implements hint:
 // %{ vm_exit_scope() %}
 func vm_exit_scope(executionScopes *types.ExecutionScopes) error {
@@ -36,3 +63,30 @@ func vm_enter_scope(executionScopes *types.ExecutionScopes) error {
 	executionScopes.EnterScope(make(map[string]interface{}))
 	return nil
 }
+
+var vmEnterScopeWithVariablesRegexp = regexp.MustCompile(VM_ENTER_SCOPE_WITH_VARIABLES_PATTERN)
+
+// Returns the `name=variable` assignments if the hint code has the form `vm_enter_scope(dict(name=variable, ...))`
+func matchVmEnterScopeWithVariables(code string) ([]string, bool) {
+	match := vmEnterScopeWithVariablesRegexp.FindStringSubmatch(code)
+	if match == nil {
+		return nil, false
+	}
+	return strings.Split(match[1], ", "), true
+}
+
+// Implements hint: vm_enter_scope(dict(name=variable, ...))
+// Each variable of the current scope (such as __dict_manager) is available in the new scope under the given name
+func vm_enter_scope_with_variables(assignments []string, executionScopes *types.ExecutionScopes) error {
+	scope := make(map[string]interface{}, len(assignments))
+	for _, assignment := range assignments {
+		name, variable, _ := strings.Cut(assignment, "=")
+		value, err := executionScopes.Get(variable)
+		if err != nil {
+			return errors.Wrapf(err, "vm_enter_scope")
+		}
+		scope[name] = value
+	}
+	executionScopes.EnterScope(scope)
+	return nil
+}
@@ -1,6 +1,7 @@
 package hints_test
 
 import (
+	"errors"
 	"testing"
 
 	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
@@ -28,6 +29,79 @@ func AddSegmentHintOk(t *testing.T) {
 	}
 }
 
+func TestVmEnterScopeWithVariablesNested(t *testing.T) {
+	vm := NewVirtualMachine()
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Code: "vm_enter_scope(dict(__dict_manager=__dict_manager))",
+	})
+	executionScopes := NewExecutionScopes()
+	executionScopes.EnterScope(map[string]interface{}{"__dict_manager": FeltFromUint64(7)})
+
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, executionScopes)
+	if err != nil {
+		t.Errorf("vm_enter_scope hint test failed with error %s", err)
+	}
+	value, err := executionScopes.Get("__dict_manager")
+	if err != nil || value != FeltFromUint64(7) {
+		t.Errorf("Expected __dict_manager to be copied into the new scope, got %v", value)
+	}
+
+	// The variable is still available after exiting the nested scope
+	executionScopes.ExitScope()
+	_, err = executionScopes.Get("__dict_manager")
+	if err != nil {
+		t.Errorf("Expected __dict_manager to survive the first exit, got error %s", err)
+	}
+
+	// And gone once the scope that defined it is exited
+	executionScopes.ExitScope()
+	_, err = executionScopes.Get("__dict_manager")
+	if err == nil {
+		t.Errorf("Expected __dict_manager to be gone after the second exit")
+	}
+}
+
+func TestVmEnterScopeWithVariablesMissingVariable(t *testing.T) {
+	vm := NewVirtualMachine()
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Code: "vm_enter_scope(dict(n=n))",
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, NewExecutionScopes())
+	if err == nil {
+		t.Errorf("vm_enter_scope hint should fail when the variable isn't in scope")
+	}
+}
+
+func TestIdsAddSegmentHint(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"output_ptr": {nil},
+		},
+		vm,
+	)
+	initial_segments := vm.Segments.Memory.NumSegments()
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: "ids.output_ptr = segments.add()",
+	})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, nil)
+	if err != nil {
+		t.Errorf("ids.output_ptr = segments.add() hint test failed with error %s", err)
+	}
+	ptr, err := idsManager.GetRelocatable("output_ptr", vm)
+	if err != nil {
+		t.Errorf("Expected ids.output_ptr to be a relocatable, got error %s", err)
+	}
+	if ptr != NewRelocatable(int(initial_segments), 0) {
+		t.Errorf("Expected ids.output_ptr to point to the new segment, got %v", ptr)
+	}
+}
+
 func TestExitScopeHintValid(t *testing.T) {
 	vm := NewVirtualMachine()
 	vm.Segments.AddSegment()
@@ -155,3 +229,57 @@ func TestEnterScope(t *testing.T) {
 		t.Errorf("TestEnterScopeHint failed with error %s", err)
 	}
 }
+
+func setupVmForMultipleHintsTest() *VirtualMachine {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	// Program consisting of a single `[ap] = 1, ap++` instruction
+	vm.Segments.Memory.Insert(NewRelocatable(0, 0), NewMaybeRelocatableFelt(FeltFromUint64(0x480680017fff8000)))
+	vm.Segments.Memory.Insert(NewRelocatable(0, 1), NewMaybeRelocatableFelt(FeltOne()))
+	// The instruction reads its (unused) op0 from [fp - 1]
+	vm.Segments.Memory.Insert(NewRelocatable(1, 0), NewMaybeRelocatableFelt(FeltZero()))
+	vm.RunContext.Pc = NewRelocatable(0, 0)
+	vm.RunContext.Ap = NewRelocatable(1, 1)
+	vm.RunContext.Fp = NewRelocatable(1, 1)
+	return vm
+}
+
+func TestMultipleHintsSamePcRunInDeclaredOrder(t *testing.T) {
+	vm := setupVmForMultipleHintsTest()
+	hintProcessor := &CairoVmHintProcessor{}
+	// Exiting the scope is only valid if the scope entered by the first hint already exists
+	hintDataMap := map[uint][]any{
+		0: {HintData{Code: VM_ENTER_SCOPE}, HintData{Code: VM_EXIT_SCOPE}},
+	}
+	constants := make(map[string]lambdaworks.Felt)
+	executionScopes := NewExecutionScopes()
+	err := vm.Step(hintProcessor, &hintDataMap, &constants, executionScopes)
+	if err != nil {
+		t.Errorf("Step with multiple hints failed with error: %s", err)
+	}
+	// Only the main scope should be left
+	err = executionScopes.ExitScope()
+	if err == nil || err.Error() != ErrCannotExitMainScop.Error() {
+		t.Errorf("Only the main scope should be left after running the hints")
+	}
+	if vm.CurrentStep != 1 {
+		t.Errorf("The instruction should run after the hints")
+	}
+}
+
+func TestMultipleHintsSamePcWrongOrderFails(t *testing.T) {
+	vm := setupVmForMultipleHintsTest()
+	hintProcessor := &CairoVmHintProcessor{}
+	hintDataMap := map[uint][]any{
+		0: {HintData{Code: VM_EXIT_SCOPE}, HintData{Code: VM_ENTER_SCOPE}},
+	}
+	constants := make(map[string]lambdaworks.Felt)
+	err := vm.Step(hintProcessor, &hintDataMap, &constants, NewExecutionScopes())
+	if !errors.Is(err, ErrCannotExitMainScop) {
+		t.Errorf("Step should fail with ErrCannotExitMainScop when the exit scope hint runs before the enter scope hint, got %v", err)
+	}
+	if vm.CurrentStep != 0 {
+		t.Errorf("The instruction should not run if a hint fails")
+	}
+}
@@ -0,0 +1,83 @@
+package hints
+
+import (
+	"math/big"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/pkg/errors"
+)
+
+// Implements hint:
+// %{ from starkware.cairo.common.cairo_secp.secp256r1_utils import SECP256R1_P as SECP_P %}
+func import_secp256r1_p(execScopes *types.ExecutionScopes) error {
+	execScopes.AssignOrUpdateVariable("SECP_P", Secp256r1P())
+	return nil
+}
+
+// Implements hint:
+// %{ from starkware.cairo.common.cairo_secp.secp256r1_utils import SECP256R1_N as N %}
+func import_secp256r1_n(execScopes *types.ExecutionScopes) error {
+	execScopes.AssignOrUpdateVariable("N", Secp256r1N())
+	return nil
+}
+
+// Implements hint:
+//
+//	%{
+//	    from starkware.cairo.common.cairo_secp.secp256r1_utils import SECP256R1_P
+//	    from starkware.cairo.common.cairo_secp.secp_utils import pack
+//	    value = pack(ids.x, PRIME) % SECP256R1_P
+//
+// %}
+func secp256r1_reduce(ids IdsManager, vm *VirtualMachine, execScopes *types.ExecutionScopes) error {
+	x, err := BigInt3FromVarName("x", ids, vm)
+	if err != nil {
+		return err
+	}
+	value := new(big.Int).Mod(x.Pack(), Secp256r1P())
+	execScopes.AssignOrUpdateVariable("value", value)
+	return nil
+}
+
+// Implements hint:
+//
+//	%{
+//	    from starkware.cairo.common.cairo_secp.secp_utils import pack
+//	    from starkware.python.math_utils import div_mod, safe_div
+//
+//	    a = pack(ids.a, PRIME)
+//	    b = pack(ids.b, PRIME)
+//	    value = res = div_mod(a, b, N)
+//
+// %}
+func div_mod_n_packed_external_n(ids IdsManager, vm *VirtualMachine, execScopes *types.ExecutionScopes) error {
+	nAny, err := execScopes.Get("N")
+	if err != nil {
+		return err
+	}
+	n, ok := nAny.(*big.Int)
+	if !ok {
+		return errors.New("div_mod_n failed: N is not an integer")
+	}
+	a, err := BigInt3FromVarName("a", ids, vm)
+	if err != nil {
+		return err
+	}
+	b, err := BigInt3FromVarName("b", ids, vm)
+	if err != nil {
+		return err
+	}
+	aPacked, bPacked := a.Pack(), b.Pack()
+	value, err := utils.DivMod(aPacked, bPacked, n)
+	if err != nil {
+		return err
+	}
+	execScopes.AssignOrUpdateVariable("a", aPacked)
+	execScopes.AssignOrUpdateVariable("b", bPacked)
+	execScopes.AssignOrUpdateVariable("value", value)
+	execScopes.AssignOrUpdateVariable("res", new(big.Int).Set(value))
+	return nil
+}
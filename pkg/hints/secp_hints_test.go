@@ -0,0 +1,92 @@
+package hints_test
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/hints/hint_utils"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/types"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	. "github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func TestSecp256r1ReduceHint(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	// Every limb is 2**86 - 1, so x = 2**258 - 1
+	limb := NewMaybeRelocatableFelt(FeltFromBigInt(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 86), big.NewInt(1))))
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"x": {limb, limb, limb},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	hintData := any(HintData{
+		Ids:  idsManager,
+		Code: SECP256R1_REDUCE,
+	})
+	scopes := NewExecutionScopes()
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err != nil {
+		t.Errorf("SECP256R1_REDUCE hint test failed with error %s", err)
+		return
+	}
+	value, err := scopes.Get("value")
+	expected, _ := new(big.Int).SetString("3fffffffbfffffffffffffffffffffffc000000000000000000000003", 16)
+	if err != nil || value.(*big.Int).Cmp(expected) != 0 {
+		t.Errorf("SECP256R1_REDUCE hint test wrong value. Expected %s, got %v", expected, value)
+	}
+}
+
+func TestDivModNPackedSecp256r1Hint(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	zero := NewMaybeRelocatableFelt(FeltZero())
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			// a = 2**172 * 2**28 + 12345 = 2**200 + 12345
+			"a": {NewMaybeRelocatableFelt(FeltFromUint64(12345)), zero, NewMaybeRelocatableFelt(FeltFromUint64(1 << 28))},
+			"b": {NewMaybeRelocatableFelt(FeltFromUint64(7)), zero, zero},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	scopes := NewExecutionScopes()
+	for _, code := range []string{IMPORT_SECP256R1_N, DIV_MOD_N_PACKED_DIVMOD_EXTERNAL_N} {
+		hintData := any(HintData{Ids: idsManager, Code: code})
+		err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+		if err != nil {
+			t.Errorf("Hint %s failed with error %s", code, err)
+			return
+		}
+	}
+	res, err := scopes.Get("res")
+	expected, _ := new(big.Int).SetString("49249248db6db70049249249249249247f1d6c319d74766f21108313238a118d", 16)
+	if err != nil || res.(*big.Int).Cmp(expected) != 0 {
+		t.Errorf("DIV_MOD_N_PACKED_DIVMOD_EXTERNAL_N hint test wrong value. Expected %s, got %v", expected, res)
+	}
+}
+
+func TestDivModNPackedSecp256r1HintNotInvertible(t *testing.T) {
+	vm := NewVirtualMachine()
+	vm.Segments.AddSegment()
+	zero := NewMaybeRelocatableFelt(FeltZero())
+	idsManager := SetupIdsForTest(
+		map[string][]*MaybeRelocatable{
+			"a": {NewMaybeRelocatableFelt(FeltOne()), zero, zero},
+			"b": {zero, zero, zero},
+		},
+		vm,
+	)
+	hintProcessor := CairoVmHintProcessor{}
+	scopes := NewExecutionScopes()
+	scopes.AssignOrUpdateVariable("N", Secp256r1N())
+	hintData := any(HintData{Ids: idsManager, Code: DIV_MOD_N_PACKED_DIVMOD_EXTERNAL_N})
+	err := hintProcessor.ExecuteHint(vm, &hintData, nil, scopes)
+	if err == nil {
+		t.Errorf("DIV_MOD_N_PACKED_DIVMOD_EXTERNAL_N hint test should have failed")
+	}
+}
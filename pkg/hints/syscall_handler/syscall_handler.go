@@ -0,0 +1,57 @@
+package syscall_handler
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/pkg/errors"
+)
+
+// Handles the system calls made by Cairo 1 contracts.
+// This is the integration point for a Starknet-like host: the casm runner forwards
+// each syscall hint to the handler, which owns the contract's state
+type SyscallHandler interface {
+	// Returns the value stored at the given address, zero if it was never written
+	StorageRead(addressDomain lambdaworks.Felt, address lambdaworks.Felt) (lambdaworks.Felt, error)
+	// Stores value at the given address
+	StorageWrite(addressDomain lambdaworks.Felt, address lambdaworks.Felt, value lambdaworks.Felt) error
+	// Returns the hash of the block with the given number
+	GetBlockHash(blockNumber uint64) (lambdaworks.Felt, error)
+}
+
+type storageKey struct {
+	addressDomain lambdaworks.Felt
+	address       lambdaworks.Felt
+}
+
+// SyscallHandler that keeps the contract storage and block hashes in memory
+type InMemorySyscallHandler struct {
+	storage     map[storageKey]lambdaworks.Felt
+	BlockHashes map[uint64]lambdaworks.Felt
+}
+
+func NewInMemorySyscallHandler() *InMemorySyscallHandler {
+	return &InMemorySyscallHandler{
+		storage:     make(map[storageKey]lambdaworks.Felt),
+		BlockHashes: make(map[uint64]lambdaworks.Felt),
+	}
+}
+
+func (h *InMemorySyscallHandler) StorageRead(addressDomain lambdaworks.Felt, address lambdaworks.Felt) (lambdaworks.Felt, error) {
+	value, ok := h.storage[storageKey{addressDomain, address}]
+	if !ok {
+		return lambdaworks.FeltZero(), nil
+	}
+	return value, nil
+}
+
+func (h *InMemorySyscallHandler) StorageWrite(addressDomain lambdaworks.Felt, address lambdaworks.Felt, value lambdaworks.Felt) error {
+	h.storage[storageKey{addressDomain, address}] = value
+	return nil
+}
+
+func (h *InMemorySyscallHandler) GetBlockHash(blockNumber uint64) (lambdaworks.Felt, error) {
+	hash, ok := h.BlockHashes[blockNumber]
+	if !ok {
+		return lambdaworks.Felt{}, errors.Errorf("Block hash not found for block number %d", blockNumber)
+	}
+	return hash, nil
+}
@@ -0,0 +1,38 @@
+package syscall_handler_test
+
+import (
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints/syscall_handler"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+)
+
+func TestStorageWriteThenRead(t *testing.T) {
+	var handler syscall_handler.SyscallHandler = syscall_handler.NewInMemorySyscallHandler()
+	domain := lambdaworks.FeltZero()
+	address := lambdaworks.FeltFromUint64(17)
+	err := handler.StorageWrite(domain, address, lambdaworks.FeltFromUint64(42))
+	if err != nil {
+		t.Errorf("StorageWrite failed with error: %s", err)
+	}
+	value, err := handler.StorageRead(domain, address)
+	if err != nil || value != lambdaworks.FeltFromUint64(42) {
+		t.Errorf("Wrong stored value. Expected 42, got %v", value)
+	}
+}
+
+func TestStorageReadUnwrittenAddress(t *testing.T) {
+	handler := syscall_handler.NewInMemorySyscallHandler()
+	value, err := handler.StorageRead(lambdaworks.FeltZero(), lambdaworks.FeltFromUint64(17))
+	if err != nil || value != lambdaworks.FeltZero() {
+		t.Errorf("Expected unwritten storage to read as zero, got %v", value)
+	}
+}
+
+func TestGetBlockHashUnknownBlock(t *testing.T) {
+	handler := syscall_handler.NewInMemorySyscallHandler()
+	_, err := handler.GetBlockHash(3)
+	if err == nil {
+		t.Errorf("GetBlockHash should fail for an unknown block")
+	}
+}
@@ -1,30 +1,26 @@
 package lambdaworks
 
-/*
-#cgo LDFLAGS: pkg/lambdaworks/lib/liblambdaworks.a -ldl
-#include "lib/lambdaworks.h"
-#include <stdlib.h>
-*/
-import "C"
-
 import (
+	"encoding/binary"
+	"fmt"
 	"math/big"
 	"strings"
-	"unsafe"
 
 	"github.com/pkg/errors"
 )
 
 const N_LIMBS_IN_FELT = 4
 
-// Go representation of a single limb (unsigned integer with 64 bits).
-type Limb C.limb_t
-
 // Go representation of a 256 bit prime field element (felt).
+// The field arithmetic is done by the lambdaworks FFI, or by math/big when building with the purego tag
 type Felt struct {
 	limbs [N_LIMBS_IN_FELT]Limb
 }
 
+var ErrSliceLengthMismatch = errors.New("Felt slices have different lengths")
+var ErrFeltBytesTooLong = errors.New("Byte slice is longer than 32 bytes")
+var ErrFeltExceedsPrime = errors.New("Value exceeds the cairo prime")
+
 func LambdaworksError(err error) error {
 	return errors.Wrapf(err, "Lambdaworks Error")
 }
@@ -33,49 +29,6 @@ func ConversionError(felt Felt, targetType string) error {
 	return LambdaworksError(errors.Errorf("Cannot convert felt: %d to %s", felt, targetType))
 }
 
-// Converts a Go Felt to a C felt_t.
-func (f Felt) toC() C.felt_t {
-	var result C.felt_t
-	for i, limb := range f.limbs {
-		result[i] = C.limb_t(limb)
-	}
-	return result
-}
-
-// Converts a C felt_t to a Go Felt.
-func fromC(result C.felt_t) Felt {
-	var limbs [N_LIMBS_IN_FELT]Limb
-	for i, limb := range result {
-		limbs[i] = Limb(limb)
-	}
-	return Felt{limbs: limbs}
-}
-
-// Gets a Felt representing the "value" number, in Montgomery format.
-func FeltFromUint64(value uint64) Felt {
-	var result C.felt_t
-	C.from(&result[0], C.uint64_t(value))
-	return fromC(result)
-}
-
-func FeltFromHex(value string) Felt {
-	cs := C.CString(value)
-	defer C.free(unsafe.Pointer(cs))
-
-	var result C.felt_t
-	C.from_hex(&result[0], cs)
-	return fromC(result)
-}
-
-func FeltFromDecString(value string) Felt {
-	cs := C.CString(value)
-	defer C.free(unsafe.Pointer(cs))
-
-	var result C.felt_t
-	C.from_dec_str(&result[0], cs)
-	return fromC(result)
-}
-
 // turns a felt to usize
 func (felt Felt) ToU64() (uint64, error) {
 	if felt.limbs[0] == 0 && felt.limbs[1] == 0 && felt.limbs[2] == 0 {
@@ -85,170 +38,166 @@ func (felt Felt) ToU64() (uint64, error) {
 	}
 }
 
-func (felt Felt) ToLeBytes() *[32]byte {
-	var result_c [32]C.uint8_t
-	var value C.felt_t = felt.toC()
-	C.to_le_bytes(&result_c[0], &value[0])
-
-	result := (*[32]byte)(unsafe.Pointer(&result_c))
-
-	return result
+// Returns the felt's value written in the given base (2, 8, 10 or 16), without prefix
+func (f Felt) ToStringRadix(base int) string {
+	return f.ToBigInt().Text(base)
 }
 
-func (felt Felt) ToBeBytes() *[32]byte {
-	var result_c [32]C.uint8_t
-	var value C.felt_t = felt.toC()
-	C.to_be_bytes(&result_c[0], &value[0])
-
-	result := (*[32]byte)(unsafe.Pointer(&result_c))
-
-	return result
-}
-
-func (felt Felt) ToHexString() string {
-	// We need to make sure enough space is allocated to fit the longest possible string
-	var result_c = C.CString(strings.Repeat(" ", 65))
-	defer C.free(unsafe.Pointer(result_c))
-
-	var value C.felt_t = felt.toC()
-	C.to_hex_string(result_c, &value[0])
-	res := C.GoString(result_c)
-	return strings.TrimSpace(res)
+// Returns the felt's value as a 0x prefixed hex string, left-padded with zeros to
+// at least width hex digits
+func (f Felt) ToHexPadded(width int) string {
+	digits := f.ToStringRadix(16)
+	if len(digits) < width {
+		digits = strings.Repeat("0", width-len(digits)) + digits
+	}
+	return "0x" + digits
 }
 
-func FeltFromLeBytes(bytes *[32]byte) Felt {
-	var result C.felt_t
-	bytes_ptr := (*[32]C.uint8_t)(unsafe.Pointer(bytes))
-	C.from_le_bytes(&result[0], &bytes_ptr[0])
-	return fromC(result)
+// Returns the felt's value in decimal, implementing fmt.Stringer
+func (f Felt) String() string {
+	return f.ToStringRadix(10)
 }
 
-func FeltFromBeBytes(bytes *[32]byte) Felt {
-	var result C.felt_t
-	bytes_ptr := (*[32]C.uint8_t)(unsafe.Pointer(bytes))
-	C.from_be_bytes(&result[0], &bytes_ptr[0])
-	return fromC(result)
+// Implements fmt.Formatter so felts are printed as numbers instead of raw limbs.
+// %v, %s and %d print the decimal value, %x/%X hex, %o octal and %b binary. Flags and width behave as for big.Int
+func (f Felt) Format(s fmt.State, verb rune) {
+	f.ToBigInt().Format(s, verb)
 }
 
-// Gets a Felt representing 0.
-func FeltZero() Felt {
-	var result C.felt_t
-	C.zero(&result[0])
-	return fromC(result)
+// Gets a Felt representing the value of n modulo the cairo prime
+func FeltFromBigInt(n *big.Int) Felt {
+	var bytes [32]byte
+	new(big.Int).Mod(n, cairoPrime()).FillBytes(bytes[:])
+	return FeltFromBeBytes(&bytes)
 }
 
-// Gets a Felt representing 1.
-func FeltOne() Felt {
-	var result C.felt_t
-	C.one(&result[0])
-	return fromC(result)
+// Gets a Felt from a little-endian byte slice of at most 32 bytes.
+// Fails with ErrFeltBytesTooLong if the slice is longer, or ErrFeltExceedsPrime if its value isn't lower than PRIME
+func FeltFromLeBytesSlice(bytes []byte) (Felt, error) {
+	if len(bytes) > 32 {
+		return Felt{}, errors.Wrapf(ErrFeltBytesTooLong, "got %d bytes", len(bytes))
+	}
+	beBytes := make([]byte, len(bytes))
+	for i, b := range bytes {
+		beBytes[len(bytes)-1-i] = b
+	}
+	return FeltFromBeBytesSlice(beBytes)
 }
 
-func (f Felt) IsZero() bool {
-	return f == FeltZero()
+// Gets a Felt from a big-endian byte slice of at most 32 bytes.
+// Fails with ErrFeltBytesTooLong if the slice is longer, or ErrFeltExceedsPrime if its value isn't lower than PRIME
+func FeltFromBeBytesSlice(bytes []byte) (Felt, error) {
+	if len(bytes) > 32 {
+		return Felt{}, errors.Wrapf(ErrFeltBytesTooLong, "got %d bytes", len(bytes))
+	}
+	value := new(big.Int).SetBytes(bytes)
+	if value.Cmp(cairoPrime()) >= 0 {
+		return Felt{}, errors.Wrapf(ErrFeltExceedsPrime, "got 0x%x", value)
+	}
+	return FeltFromBigInt(value), nil
 }
 
-// Writes the result variable with the sum of a and b felts.
-func (a Felt) Add(b Felt) Felt {
-	var result C.felt_t
-	var a_c C.felt_t = a.toC()
-	var b_c C.felt_t = b.toC()
-	C.add(&a_c[0], &b_c[0], &result[0])
-	return fromC(result)
+// Returns the felt's limbs, most significant limb first.
+// Felts are always stored in their canonical form (the representative in [0, PRIME)),
+// so two felts are equal if and only if their keys are equal, which makes
+// the key suitable for building custom hash structures
+func (f Felt) HashKey() [N_LIMBS_IN_FELT]uint64 {
+	var key [N_LIMBS_IN_FELT]uint64
+	for i, limb := range f.limbs {
+		key[i] = uint64(limb)
+	}
+	return key
 }
 
-// Writes the result variable with a - b.
-func (a Felt) Sub(b Felt) Felt {
-	var result C.felt_t
-	var a_c C.felt_t = a.toC()
-	var b_c C.felt_t = b.toC()
-	C.sub(&a_c[0], &b_c[0], &result[0])
-	return fromC(result)
+// Returns the felt's limbs in canonical form (the representative in [0, PRIME)), most significant limb first.
+// This is the form in which felts are stored and exchanged with the lambdaworks FFI
+func (f Felt) CanonicalLimbs() [N_LIMBS_IN_FELT]uint64 {
+	return f.HashKey()
 }
 
-// Writes the result variable with a * b.
-func (a Felt) Mul(b Felt) Felt {
-	var result C.felt_t
-	var a_c C.felt_t = a.toC()
-	var b_c C.felt_t = b.toC()
-	C.mul(&a_c[0], &b_c[0], &result[0])
-	return fromC(result)
+// Gets a Felt from its limbs in canonical form, most significant limb first.
+// Values greater or equal than PRIME are reduced
+func FeltFromCanonicalLimbs(limbs [N_LIMBS_IN_FELT]uint64) Felt {
+	return FeltFromBigInt(limbsToBigInt(limbs))
 }
 
-// Writes the result variable with a / b.
-func (a Felt) Div(b Felt) Felt {
-	var result C.felt_t
-	var a_c C.felt_t = a.toC()
-	var b_c C.felt_t = b.toC()
-	C.lw_div(&a_c[0], &b_c[0], &result[0])
-	return fromC(result)
+// Returns the felt's limbs in Montgomery form (f * 2^256 mod PRIME), most significant limb first.
+// This is the form lambdaworks uses internally for field elements
+func (f Felt) Limbs() [N_LIMBS_IN_FELT]uint64 {
+	prime := cairoPrime()
+	montgomery := new(big.Int).Lsh(f.ToBigInt(), 64*N_LIMBS_IN_FELT)
+	return bigIntToLimbs(montgomery.Mod(montgomery, prime))
 }
 
-// Returns the felt
-func (f Felt) ToSignedFeltString() string {
-	var f_c = f.toC()
-	resultPtr := C.to_signed_felt(&f_c[0])
-	defer C.free_string(resultPtr)
-	goResult := C.GoString(resultPtr)
-	return goResult
+// Gets a Felt from its limbs in Montgomery form (f * 2^256 mod PRIME), most significant limb first
+func FeltFromLimbs(limbs [N_LIMBS_IN_FELT]uint64) Felt {
+	prime := cairoPrime()
+	rInv := new(big.Int).Lsh(big.NewInt(1), 64*N_LIMBS_IN_FELT)
+	rInv.ModInverse(rInv.Mod(rInv, prime), prime)
+	value := new(big.Int).Mul(limbsToBigInt(limbs), rInv)
+	return FeltFromBigInt(value.Mod(value, prime))
 }
 
-// Returns the number of bits needed to represent the felt
-func (a Felt) Bits() C.limb_t {
-	if a.IsZero() {
-		return 0
+// Converts limbs (most significant limb first) into the number they represent
+func limbsToBigInt(limbs [N_LIMBS_IN_FELT]uint64) *big.Int {
+	n := new(big.Int)
+	for _, limb := range limbs {
+		n.Lsh(n, 64)
+		n.Or(n, new(big.Int).SetUint64(limb))
 	}
-	var a_c = a.toC()
-	return C.bits(&a_c[0])
+	return n
 }
 
-func (a Felt) And(b Felt) Felt {
-	var result C.felt_t
-	var a_c C.felt_t = a.toC()
-	var b_c C.felt_t = b.toC()
-	C.felt_and(&a_c[0], &b_c[0], &result[0])
-	return fromC(result)
+// Converts a number lower than 2^256 into its limbs, most significant limb first
+func bigIntToLimbs(n *big.Int) [N_LIMBS_IN_FELT]uint64 {
+	var bytes [32]byte
+	n.FillBytes(bytes[:])
+	var limbs [N_LIMBS_IN_FELT]uint64
+	for i := range limbs {
+		limbs[i] = binary.BigEndian.Uint64(bytes[8*i : 8*(i+1)])
+	}
+	return limbs
 }
 
-func (a Felt) Xor(b Felt) Felt {
-	var result C.felt_t
-	var a_c C.felt_t = a.toC()
-	var b_c C.felt_t = b.toC()
-	C.felt_xor(&a_c[0], &b_c[0], &result[0])
-	return fromC(result)
+func (f Felt) IsZero() bool {
+	return f == FeltZero()
 }
 
-func (a Felt) Or(b Felt) Felt {
-	var result C.felt_t
-	var a_c C.felt_t = a.toC()
-	var b_c C.felt_t = b.toC()
-	C.felt_or(&a_c[0], &b_c[0], &result[0])
-	return fromC(result)
+// Returns a^exp, using square-and-multiply over the exponent's bits
+// Unlike PowUint, the exponent can be any felt
+func (a Felt) Pow(exp Felt) Felt {
+	result := FeltOne()
+	expBig := exp.ToBigInt()
+	for i := expBig.BitLen() - 1; i >= 0; i-- {
+		result = result.Mul(result)
+		if expBig.Bit(i) == 1 {
+			result = result.Mul(a)
+		}
+	}
+	return result
 }
 
-func (a Felt) Shl(num uint64) Felt {
-	var result C.felt_t
-	var a_c C.felt_t = a.toC()
-
-	C.felt_shl(&a_c[0], C.uint64_t(num), &result[0])
-	return fromC(result)
+// Returns the element-wise sum of a and b, crossing the FFI once for the whole slices.
+// Fails with ErrSliceLengthMismatch if they have different lengths
+func AddSlice(a []Felt, b []Felt) ([]Felt, error) {
+	if len(a) != len(b) {
+		return nil, errors.Wrapf(ErrSliceLengthMismatch, "%d and %d", len(a), len(b))
+	}
+	return addSlice(a, b), nil
 }
 
-func (a Felt) PowUint(p uint32) Felt {
-	var result C.felt_t
-	var a_c C.felt_t = a.toC()
-
-	C.felt_pow_uint(&a_c[0], C.uint(p), &result[0])
-	return fromC(result)
+// Returns the element-wise product of a and b, crossing the FFI once for the whole slices.
+// Fails with ErrSliceLengthMismatch if they have different lengths
+func MulSlice(a []Felt, b []Felt) ([]Felt, error) {
+	if len(a) != len(b) {
+		return nil, errors.Wrapf(ErrSliceLengthMismatch, "%d and %d", len(a), len(b))
+	}
+	return mulSlice(a, b), nil
 }
 
+// Returns the felt's value shifted b bits to the right
 func (a Felt) Shr(b uint) Felt {
-	var result C.felt_t
-	var a_c C.felt_t = a.toC()
-	//var b_c C._type_uint = b.toC()
-	C.felt_shr(&a_c[0], C.size_t(b), &result[0])
-	//C.felt_shr(&a_c[0], &b_c[0], &result[0])
-	return fromC(result)
+	return FeltFromBigInt(new(big.Int).Rsh(a.ToBigInt(), b))
 }
 
 func (f Felt) ToBigInt() *big.Int {
@@ -258,24 +207,60 @@ func (f Felt) ToBigInt() *big.Int {
 const CAIRO_PRIME_HEX = "0x800000000000011000000000000000000000000000000000000000000000001"
 const SIGNED_FELT_MAX_HEX = "0x400000000000008800000000000000000000000000000000000000000000000"
 
+// Order of the STARK curve's generator, the modulus of the curve's scalars
+const STARK_CURVE_ORDER_HEX = "0x800000000000010ffffffffffffffffb781126dcae7b2321e66a241adc64d2f"
+
+func cairoPrime() *big.Int {
+	prime, _ := new(big.Int).SetString(CAIRO_PRIME_HEX, 0)
+	return prime
+}
+
+// Returns the felt as a scalar of the STARK curve (as used by EC multiplications and ECDSA), reduced modulo the curve's order
+func (f Felt) ToStarkCurveScalar() *big.Int {
+	order, _ := new(big.Int).SetString(STARK_CURVE_ORDER_HEX, 0)
+	return new(big.Int).Mod(f.ToBigInt(), order)
+}
+
+// Gets the Felt representing an element of the STARK curve's base field. As the curve is defined over the
+// cairo prime field, point coordinates map directly to felts, values outside of the field are reduced modulo the prime
+func FeltFromStarkCurveField(value *big.Int) Felt {
+	return FeltFromBigInt(value)
+}
+
 // Implements `as_int` behaviour
 func (f Felt) ToSigned() *big.Int {
 	n := f.ToBigInt()
 	signedFeltMax, _ := new(big.Int).SetString(SIGNED_FELT_MAX_HEX, 0)
 	if n.Cmp(signedFeltMax) == 1 {
-		cairoPrime, _ := new(big.Int).SetString(CAIRO_PRIME_HEX, 0)
-		return new(big.Int).Neg(new(big.Int).Sub(cairoPrime, n))
+		return new(big.Int).Neg(new(big.Int).Sub(cairoPrime(), n))
 	}
 	return n
 }
 
-func (a Felt) DivRem(b Felt) (Felt, Felt) {
-	var div C.felt_t
-	var rem C.felt_t
-	var a_c C.felt_t = a.toC()
-	var b_c C.felt_t = b.toC()
-	C.div_rem(&a_c[0], &b_c[0], &div[0], &rem[0])
-	return fromC(div), fromC(rem)
+// Returns true if the felt is a quadratic residue modulo the cairo prime, computing
+// the Legendre symbol as f^((p-1)/2). Zero is considered a residue (as in python's
+// `is_quad_residue(x, PRIME)`)
+func (f Felt) IsQuadraticResidue() bool {
+	if f.IsZero() {
+		return true
+	}
+	// SIGNED_FELT_MAX is (p - 1) / 2
+	return f.Pow(FeltFromHex(SIGNED_FELT_MAX_HEX)) == FeltOne()
+}
+
+// Returns the smallest square root of the felt (as in python's `sqrt(x, PRIME)`)
+// Returns false if the felt is not a quadratic residue
+func (f Felt) Sqrt() (Felt, bool) {
+	prime := cairoPrime()
+	root := new(big.Int).ModSqrt(f.ToBigInt(), prime)
+	if root == nil {
+		return FeltZero(), false
+	}
+	otherRoot := new(big.Int).Sub(prime, root)
+	if otherRoot.Cmp(root) == -1 {
+		root = otherRoot
+	}
+	return FeltFromBigInt(root), true
 }
 
 func (a Felt) ModFloor(b Felt) Felt {
@@ -288,15 +273,10 @@ func (a Felt) DivFloor(b Felt) Felt {
 	return div
 }
 
-/*
-Compares x and y and returns:
-
-	-1 if a <  b
-	 0 if a == b
-	+1 if a >  b
-*/
-func (a Felt) Cmp(b Felt) int {
-	var a_c C.felt_t = a.toC()
-	var b_c C.felt_t = b.toC()
-	return int(C.cmp(&a_c[0], &b_c[0]))
+// Returns the quotient and remainder of python's `divmod(a, b)`, interpreting both felts as
+// their canonical non-negative integers (the representatives in [0, PRIME)) instead of field elements.
+// Panics if b is zero, as python raises ZeroDivisionError
+func (a Felt) PyDivMod(b Felt) (Felt, Felt) {
+	quotient, remainder := new(big.Int).DivMod(a.ToBigInt(), b.ToBigInt(), new(big.Int))
+	return FeltFromBigInt(quotient), FeltFromBigInt(remainder)
 }
@@ -0,0 +1,268 @@
+//go:build !purego
+
+package lambdaworks
+
+/*
+#cgo LDFLAGS: pkg/lambdaworks/lib/liblambdaworks.a -ldl
+#include "lib/lambdaworks.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"strings"
+	"unsafe"
+)
+
+// Go representation of a single limb (unsigned integer with 64 bits).
+type Limb C.limb_t
+
+// Converts a Go Felt to a C felt_t.
+func (f Felt) toC() C.felt_t {
+	var result C.felt_t
+	for i, limb := range f.limbs {
+		result[i] = C.limb_t(limb)
+	}
+	return result
+}
+
+// Converts a C felt_t to a Go Felt.
+func fromC(result C.felt_t) Felt {
+	var limbs [N_LIMBS_IN_FELT]Limb
+	for i, limb := range result {
+		limbs[i] = Limb(limb)
+	}
+	return Felt{limbs: limbs}
+}
+
+// Gets a Felt representing the "value" number, in Montgomery format.
+func FeltFromUint64(value uint64) Felt {
+	var result C.felt_t
+	C.from(&result[0], C.uint64_t(value))
+	return fromC(result)
+}
+
+func FeltFromHex(value string) Felt {
+	cs := C.CString(value)
+	defer C.free(unsafe.Pointer(cs))
+
+	var result C.felt_t
+	C.from_hex(&result[0], cs)
+	return fromC(result)
+}
+
+func FeltFromDecString(value string) Felt {
+	cs := C.CString(value)
+	defer C.free(unsafe.Pointer(cs))
+
+	var result C.felt_t
+	C.from_dec_str(&result[0], cs)
+	return fromC(result)
+}
+
+func (felt Felt) ToLeBytes() *[32]byte {
+	var result_c [32]C.uint8_t
+	var value C.felt_t = felt.toC()
+	C.to_le_bytes(&result_c[0], &value[0])
+
+	result := (*[32]byte)(unsafe.Pointer(&result_c))
+
+	return result
+}
+
+func (felt Felt) ToBeBytes() *[32]byte {
+	var result_c [32]C.uint8_t
+	var value C.felt_t = felt.toC()
+	C.to_be_bytes(&result_c[0], &value[0])
+
+	result := (*[32]byte)(unsafe.Pointer(&result_c))
+
+	return result
+}
+
+func (felt Felt) ToHexString() string {
+	// We need to make sure enough space is allocated to fit the longest possible string
+	var result_c = C.CString(strings.Repeat(" ", 65))
+	defer C.free(unsafe.Pointer(result_c))
+
+	var value C.felt_t = felt.toC()
+	C.to_hex_string(result_c, &value[0])
+	res := C.GoString(result_c)
+	return strings.TrimSpace(res)
+}
+
+func FeltFromLeBytes(bytes *[32]byte) Felt {
+	var result C.felt_t
+	bytes_ptr := (*[32]C.uint8_t)(unsafe.Pointer(bytes))
+	C.from_le_bytes(&result[0], &bytes_ptr[0])
+	return fromC(result)
+}
+
+func FeltFromBeBytes(bytes *[32]byte) Felt {
+	var result C.felt_t
+	bytes_ptr := (*[32]C.uint8_t)(unsafe.Pointer(bytes))
+	C.from_be_bytes(&result[0], &bytes_ptr[0])
+	return fromC(result)
+}
+
+// Gets a Felt representing 0.
+func FeltZero() Felt {
+	var result C.felt_t
+	C.zero(&result[0])
+	return fromC(result)
+}
+
+// Gets a Felt representing 1.
+func FeltOne() Felt {
+	var result C.felt_t
+	C.one(&result[0])
+	return fromC(result)
+}
+
+// Writes the result variable with the sum of a and b felts.
+func (a Felt) Add(b Felt) Felt {
+	var result C.felt_t
+	var a_c C.felt_t = a.toC()
+	var b_c C.felt_t = b.toC()
+	C.add(&a_c[0], &b_c[0], &result[0])
+	return fromC(result)
+}
+
+// Writes the result variable with a - b.
+func (a Felt) Sub(b Felt) Felt {
+	var result C.felt_t
+	var a_c C.felt_t = a.toC()
+	var b_c C.felt_t = b.toC()
+	C.sub(&a_c[0], &b_c[0], &result[0])
+	return fromC(result)
+}
+
+// Writes the result variable with a * b.
+func (a Felt) Mul(b Felt) Felt {
+	var result C.felt_t
+	var a_c C.felt_t = a.toC()
+	var b_c C.felt_t = b.toC()
+	C.mul(&a_c[0], &b_c[0], &result[0])
+	return fromC(result)
+}
+
+// Applies a slice operation of the FFI over the felts of a and b, which have the same length, in a single call
+func sliceOp(a []Felt, b []Felt, op func(a *C.felt_t, b *C.felt_t, result *C.felt_t, len C.size_t)) []Felt {
+	if len(a) == 0 {
+		return []Felt{}
+	}
+	a_c := make([]C.felt_t, len(a))
+	b_c := make([]C.felt_t, len(b))
+	for i := range a {
+		a_c[i] = a[i].toC()
+		b_c[i] = b[i].toC()
+	}
+	result_c := make([]C.felt_t, len(a))
+	op(&a_c[0], &b_c[0], &result_c[0], C.size_t(len(a)))
+	result := make([]Felt, len(a))
+	for i, felt := range result_c {
+		result[i] = fromC(felt)
+	}
+	return result
+}
+
+func addSlice(a []Felt, b []Felt) []Felt {
+	return sliceOp(a, b, func(a *C.felt_t, b *C.felt_t, result *C.felt_t, len C.size_t) {
+		C.add_slice(a, b, result, len)
+	})
+}
+
+func mulSlice(a []Felt, b []Felt) []Felt {
+	return sliceOp(a, b, func(a *C.felt_t, b *C.felt_t, result *C.felt_t, len C.size_t) {
+		C.mul_slice(a, b, result, len)
+	})
+}
+
+// Writes the result variable with a / b.
+func (a Felt) Div(b Felt) Felt {
+	var result C.felt_t
+	var a_c C.felt_t = a.toC()
+	var b_c C.felt_t = b.toC()
+	C.lw_div(&a_c[0], &b_c[0], &result[0])
+	return fromC(result)
+}
+
+// Returns the felt
+func (f Felt) ToSignedFeltString() string {
+	var f_c = f.toC()
+	resultPtr := C.to_signed_felt(&f_c[0])
+	defer C.free_string(resultPtr)
+	goResult := C.GoString(resultPtr)
+	return goResult
+}
+
+// Returns the number of bits needed to represent the felt
+func (a Felt) Bits() Limb {
+	if a.IsZero() {
+		return 0
+	}
+	var a_c = a.toC()
+	return Limb(C.bits(&a_c[0]))
+}
+
+func (a Felt) And(b Felt) Felt {
+	var result C.felt_t
+	var a_c C.felt_t = a.toC()
+	var b_c C.felt_t = b.toC()
+	C.felt_and(&a_c[0], &b_c[0], &result[0])
+	return fromC(result)
+}
+
+func (a Felt) Xor(b Felt) Felt {
+	var result C.felt_t
+	var a_c C.felt_t = a.toC()
+	var b_c C.felt_t = b.toC()
+	C.felt_xor(&a_c[0], &b_c[0], &result[0])
+	return fromC(result)
+}
+
+func (a Felt) Or(b Felt) Felt {
+	var result C.felt_t
+	var a_c C.felt_t = a.toC()
+	var b_c C.felt_t = b.toC()
+	C.felt_or(&a_c[0], &b_c[0], &result[0])
+	return fromC(result)
+}
+
+func (a Felt) Shl(num uint64) Felt {
+	var result C.felt_t
+	var a_c C.felt_t = a.toC()
+
+	C.felt_shl(&a_c[0], C.uint64_t(num), &result[0])
+	return fromC(result)
+}
+
+func (a Felt) PowUint(p uint32) Felt {
+	var result C.felt_t
+	var a_c C.felt_t = a.toC()
+
+	C.felt_pow_uint(&a_c[0], C.uint(p), &result[0])
+	return fromC(result)
+}
+
+func (a Felt) DivRem(b Felt) (Felt, Felt) {
+	var div C.felt_t
+	var rem C.felt_t
+	var a_c C.felt_t = a.toC()
+	var b_c C.felt_t = b.toC()
+	C.div_rem(&a_c[0], &b_c[0], &div[0], &rem[0])
+	return fromC(div), fromC(rem)
+}
+
+/*
+Compares x and y and returns:
+
+	-1 if a <  b
+	 0 if a == b
+	+1 if a >  b
+*/
+func (a Felt) Cmp(b Felt) int {
+	var a_c C.felt_t = a.toC()
+	var b_c C.felt_t = b.toC()
+	return int(C.cmp(&a_c[0], &b_c[0]))
+}
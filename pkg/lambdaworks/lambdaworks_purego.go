@@ -0,0 +1,194 @@
+//go:build purego
+
+package lambdaworks
+
+import (
+	"encoding/binary"
+	"math/big"
+	"strings"
+)
+
+// Go representation of a single limb (unsigned integer with 64 bits).
+type Limb uint64
+
+// Gets the Felt holding n, which must already be in [0, PRIME)
+func feltFromReduced(n *big.Int) Felt {
+	var limbs [N_LIMBS_IN_FELT]Limb
+	for i, limb := range bigIntToLimbs(n) {
+		limbs[i] = Limb(limb)
+	}
+	return Felt{limbs: limbs}
+}
+
+// Gets the Felt representing n modulo the cairo prime
+func feltFromUnreduced(n *big.Int) Felt {
+	return feltFromReduced(new(big.Int).Mod(n, cairoPrime()))
+}
+
+// Gets a Felt representing the "value" number.
+func FeltFromUint64(value uint64) Felt {
+	return feltFromReduced(new(big.Int).SetUint64(value))
+}
+
+// Gets a Felt from a hex string, with or without 0x prefix. Values greater or equal than PRIME are reduced.
+// Panics if the string isn't a valid hex number of at most 256 bits, as the lambdaworks FFI does
+func FeltFromHex(value string) Felt {
+	digits := strings.TrimPrefix(value, "0x")
+	n, ok := new(big.Int).SetString(digits, 16)
+	if !ok || n.Sign() < 0 || strings.HasPrefix(digits, "+") || n.BitLen() > 256 {
+		panic("Failed to convert hexadecimal string to FieldElement.")
+	}
+	return feltFromUnreduced(n)
+}
+
+// Gets a Felt from a decimal string, which can be negative. Values greater or equal than PRIME are reduced.
+// Panics if the string isn't a valid decimal number of at most 256 bits, as the lambdaworks FFI does
+func FeltFromDecString(value string) Felt {
+	digits, negative := strings.CutPrefix(value, "-")
+	n, ok := new(big.Int).SetString(digits, 10)
+	if !ok || n.Sign() < 0 || strings.HasPrefix(digits, "+") || n.BitLen() > 256 {
+		panic("Failed to convert decimal string to FieldElement.")
+	}
+	if negative {
+		n.Neg(n)
+	}
+	return feltFromUnreduced(n)
+}
+
+func (felt Felt) ToLeBytes() *[32]byte {
+	bytes := felt.ToBeBytes()
+	for i := 0; i < 16; i++ {
+		bytes[i], bytes[31-i] = bytes[31-i], bytes[i]
+	}
+	return bytes
+}
+
+func (felt Felt) ToBeBytes() *[32]byte {
+	var bytes [32]byte
+	for i, limb := range felt.limbs {
+		binary.BigEndian.PutUint64(bytes[8*i:8*(i+1)], uint64(limb))
+	}
+	return &bytes
+}
+
+func (felt Felt) ToHexString() string {
+	return "0x" + felt.ToBigInt().Text(16)
+}
+
+func FeltFromLeBytes(bytes *[32]byte) Felt {
+	var beBytes [32]byte
+	for i, b := range bytes {
+		beBytes[31-i] = b
+	}
+	return FeltFromBeBytes(&beBytes)
+}
+
+func FeltFromBeBytes(bytes *[32]byte) Felt {
+	return feltFromUnreduced(new(big.Int).SetBytes(bytes[:]))
+}
+
+// Gets a Felt representing 0.
+func FeltZero() Felt {
+	return Felt{}
+}
+
+// Gets a Felt representing 1.
+func FeltOne() Felt {
+	return FeltFromUint64(1)
+}
+
+// Writes the result variable with the sum of a and b felts.
+func (a Felt) Add(b Felt) Felt {
+	return feltFromUnreduced(new(big.Int).Add(a.ToBigInt(), b.ToBigInt()))
+}
+
+// Writes the result variable with a - b.
+func (a Felt) Sub(b Felt) Felt {
+	return feltFromUnreduced(new(big.Int).Sub(a.ToBigInt(), b.ToBigInt()))
+}
+
+// Writes the result variable with a * b.
+func (a Felt) Mul(b Felt) Felt {
+	return feltFromUnreduced(new(big.Int).Mul(a.ToBigInt(), b.ToBigInt()))
+}
+
+func addSlice(a []Felt, b []Felt) []Felt {
+	result := make([]Felt, len(a))
+	for i := range a {
+		result[i] = a[i].Add(b[i])
+	}
+	return result
+}
+
+func mulSlice(a []Felt, b []Felt) []Felt {
+	result := make([]Felt, len(a))
+	for i := range a {
+		result[i] = a[i].Mul(b[i])
+	}
+	return result
+}
+
+// Writes the result variable with a / b.
+// Panics if b is zero, as the lambdaworks FFI does
+func (a Felt) Div(b Felt) Felt {
+	if b.IsZero() {
+		panic("Division by zero error.")
+	}
+	inverse := new(big.Int).ModInverse(b.ToBigInt(), cairoPrime())
+	return feltFromUnreduced(inverse.Mul(inverse, a.ToBigInt()))
+}
+
+// Returns the felt
+func (f Felt) ToSignedFeltString() string {
+	return f.ToSigned().String()
+}
+
+// Returns the number of bits needed to represent the felt
+func (a Felt) Bits() Limb {
+	return Limb(a.ToBigInt().BitLen())
+}
+
+func (a Felt) And(b Felt) Felt {
+	return feltFromReduced(new(big.Int).And(a.ToBigInt(), b.ToBigInt()))
+}
+
+// The xor and or of two values lower than PRIME can be greater than it, so they are reduced
+func (a Felt) Xor(b Felt) Felt {
+	return feltFromUnreduced(new(big.Int).Xor(a.ToBigInt(), b.ToBigInt()))
+}
+
+func (a Felt) Or(b Felt) Felt {
+	return feltFromUnreduced(new(big.Int).Or(a.ToBigInt(), b.ToBigInt()))
+}
+
+// Bits shifted beyond the 256th are lost before reducing the result, as in the lambdaworks FFI
+func (a Felt) Shl(num uint64) Felt {
+	if num >= 64*N_LIMBS_IN_FELT {
+		return FeltZero()
+	}
+	shifted := new(big.Int).Lsh(a.ToBigInt(), uint(num))
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 64*N_LIMBS_IN_FELT), big.NewInt(1))
+	return feltFromUnreduced(shifted.And(shifted, mask))
+}
+
+func (a Felt) PowUint(p uint32) Felt {
+	return feltFromReduced(new(big.Int).Exp(a.ToBigInt(), big.NewInt(int64(p)), cairoPrime()))
+}
+
+// Integer division of the felts' representatives.
+// Panics if b is zero, as the lambdaworks FFI does
+func (a Felt) DivRem(b Felt) (Felt, Felt) {
+	div, rem := new(big.Int).DivMod(a.ToBigInt(), b.ToBigInt(), new(big.Int))
+	return feltFromReduced(div), feltFromReduced(rem)
+}
+
+/*
+Compares x and y and returns:
+
+	-1 if a <  b
+	 0 if a == b
+	+1 if a >  b
+*/
+func (a Felt) Cmp(b Felt) int {
+	return a.ToBigInt().Cmp(b.ToBigInt())
+}
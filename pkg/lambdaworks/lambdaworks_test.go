@@ -1,6 +1,8 @@
 package lambdaworks_test
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
@@ -116,6 +118,42 @@ func TestToHexPrimeMinusOne(t *testing.T) {
 	}
 }
 
+func TestToStringRadix(t *testing.T) {
+	felt := lambdaworks.FeltFromUint64(10)
+	expected := map[int]string{2: "1010", 8: "12", 10: "10", 16: "a"}
+	for base, expectedString := range expected {
+		result := felt.ToStringRadix(base)
+		if result != expectedString {
+			t.Errorf("TestToStringRadix failed for base %d. Expected: %v, Got: %v", base, expectedString, result)
+		}
+	}
+}
+
+func TestToHexPadded(t *testing.T) {
+	felt := lambdaworks.FeltFromUint64(26)
+	if result := felt.ToHexPadded(8); result != "0x0000001a" {
+		t.Errorf("TestToHexPadded failed. Expected: 0x0000001a, Got: %v", result)
+	}
+	// The value is never truncated
+	if result := felt.ToHexPadded(1); result != "0x1a" {
+		t.Errorf("TestToHexPadded failed. Expected: 0x1a, Got: %v", result)
+	}
+}
+
+func TestFeltFormatting(t *testing.T) {
+	felt := lambdaworks.FeltFromUint64(26)
+	expected := map[string]string{"%v": "26", "%s": "26", "%d": "26", "%x": "1a", "%#x": "0x1a", "%X": "1A"}
+	for format, expectedString := range expected {
+		result := fmt.Sprintf(format, felt)
+		if result != expectedString {
+			t.Errorf("TestFeltFormatting failed for %s. Expected: %v, Got: %v", format, expectedString, result)
+		}
+	}
+	if result := felt.String(); result != "26" {
+		t.Errorf("TestFeltFormatting failed. Expected: 26, Got: %v", result)
+	}
+}
+
 func TestFromDecString(t *testing.T) {
 	var s_one = "435"
 	expected := lambdaworks.FeltFromUint64(435)
@@ -520,3 +558,303 @@ func TestRelocatableToString(t *testing.T) {
 	}
 
 }
+
+func TestHashKeyEqualFelts(t *testing.T) {
+	a := lambdaworks.FeltFromUint64(26)
+	b := lambdaworks.FeltFromHex("0x1a")
+	if a.HashKey() != b.HashKey() {
+		t.Errorf("TestHashKeyEqualFelts failed. Expected equal keys, Got: %v, %v", a.HashKey(), b.HashKey())
+	}
+}
+
+func TestHashKeyReducedFelts(t *testing.T) {
+	a := lambdaworks.FeltFromDecString("-1")
+	b := lambdaworks.FeltZero().Sub(lambdaworks.FeltOne())
+	if a.HashKey() != b.HashKey() {
+		t.Errorf("TestHashKeyReducedFelts failed. Expected equal keys, Got: %v, %v", a.HashKey(), b.HashKey())
+	}
+}
+
+func TestHashKeyDifferentFelts(t *testing.T) {
+	a := lambdaworks.FeltFromUint64(26)
+	b := lambdaworks.FeltFromUint64(27)
+	if a.HashKey() == b.HashKey() {
+		t.Errorf("TestHashKeyDifferentFelts failed. Expected different keys for %v and %v", a, b)
+	}
+}
+
+func TestHashKeyLimbs(t *testing.T) {
+	a := lambdaworks.FeltFromUint64(26)
+	expected := [4]uint64{0, 0, 0, 26}
+	if a.HashKey() != expected {
+		t.Errorf("TestHashKeyLimbs failed. Expected: %v, Got: %v", expected, a.HashKey())
+	}
+}
+
+func TestFeltFromBigInt(t *testing.T) {
+	felt := lambdaworks.FeltFromBigInt(big.NewInt(26))
+	if felt != lambdaworks.FeltFromUint64(26) {
+		t.Errorf("TestFeltFromBigInt failed. Expected: 26, Got: %v", felt)
+	}
+}
+
+func TestFeltFromBigIntNegative(t *testing.T) {
+	felt := lambdaworks.FeltFromBigInt(big.NewInt(-1))
+	if felt != lambdaworks.FeltFromDecString("-1") {
+		t.Errorf("TestFeltFromBigIntNegative failed. Expected: -1, Got: %v", felt)
+	}
+}
+
+func TestFeltSqrt(t *testing.T) {
+	felt := lambdaworks.FeltFromUint64(25)
+	root, ok := felt.Sqrt()
+	if !ok || root != lambdaworks.FeltFromUint64(5) {
+		t.Errorf("TestFeltSqrt failed. Expected: 5, Got: %v", root)
+	}
+}
+
+func TestFeltSqrtNonResidue(t *testing.T) {
+	felt := lambdaworks.FeltFromUint64(3)
+	_, ok := felt.Sqrt()
+	if ok {
+		t.Errorf("TestFeltSqrtNonResidue failed. 3 should not have a square root")
+	}
+}
+
+func TestFeltIsQuadraticResidueAgreesWithSqrt(t *testing.T) {
+	for i := uint64(0); i < 50; i++ {
+		felt := lambdaworks.FeltFromUint64(i)
+		_, hasRoot := felt.Sqrt()
+		if felt.IsQuadraticResidue() != hasRoot {
+			t.Errorf("TestFeltIsQuadraticResidueAgreesWithSqrt failed for %d. IsQuadraticResidue: %v, Sqrt found root: %v", i, felt.IsQuadraticResidue(), hasRoot)
+		}
+	}
+}
+
+func TestFeltIsQuadraticResidueNonResidue(t *testing.T) {
+	felt := lambdaworks.FeltFromUint64(3)
+	if felt.IsQuadraticResidue() {
+		t.Errorf("TestFeltIsQuadraticResidueNonResidue failed. 3 should not be a quadratic residue")
+	}
+}
+
+func TestFeltLimbsRoundTrip(t *testing.T) {
+	felts := []lambdaworks.Felt{lambdaworks.FeltZero(), lambdaworks.FeltOne(), lambdaworks.FeltFromUint64(26), lambdaworks.FeltFromDecString("-1")}
+	for _, felt := range felts {
+		if lambdaworks.FeltFromLimbs(felt.Limbs()) != felt {
+			t.Errorf("TestFeltLimbsRoundTrip failed for %v", felt)
+		}
+		if lambdaworks.FeltFromCanonicalLimbs(felt.CanonicalLimbs()) != felt {
+			t.Errorf("TestFeltLimbsRoundTrip failed for canonical limbs of %v", felt)
+		}
+	}
+}
+
+func TestFeltOneMontgomeryLimbs(t *testing.T) {
+	// 2^256 mod PRIME
+	expected := [4]uint64{576460752303422960, 18446744073709551615, 18446744073709551615, 18446744073709551585}
+	if lambdaworks.FeltOne().Limbs() != expected {
+		t.Errorf("TestFeltOneMontgomeryLimbs failed. Expected: %v, Got: %v", expected, lambdaworks.FeltOne().Limbs())
+	}
+	if lambdaworks.FeltOne().CanonicalLimbs() != [4]uint64{0, 0, 0, 1} {
+		t.Errorf("TestFeltOneMontgomeryLimbs failed. Expected canonical limbs: [0 0 0 1], Got: %v", lambdaworks.FeltOne().CanonicalLimbs())
+	}
+}
+
+func TestFeltFromCanonicalLimbsReduces(t *testing.T) {
+	// PRIME + 1
+	limbs := [4]uint64{576460752303423505, 0, 0, 2}
+	if lambdaworks.FeltFromCanonicalLimbs(limbs) != lambdaworks.FeltOne() {
+		t.Errorf("TestFeltFromCanonicalLimbsReduces failed. Expected: 1, Got: %v", lambdaworks.FeltFromCanonicalLimbs(limbs))
+	}
+}
+
+func TestFeltPowFermat(t *testing.T) {
+	// g^(p-1) == 1 for any non-zero g
+	g := lambdaworks.FeltFromUint64(3)
+	result := g.Pow(lambdaworks.FeltFromDecString("-1"))
+	if result != lambdaworks.FeltOne() {
+		t.Errorf("TestFeltPowFermat failed. Expected: 1, Got: %v", result)
+	}
+}
+
+func TestFeltPowLargeExponent(t *testing.T) {
+	// 2^252 mod p
+	expected := lambdaworks.FeltFromHex("0x7ffffffffffffeeffffffffffffffffffffffffffffffffffffffffffffffff")
+	result := lambdaworks.FeltFromUint64(2).Pow(lambdaworks.FeltFromUint64(252))
+	if result != expected {
+		t.Errorf("TestFeltPowLargeExponent failed. Expected: %v, Got: %v", expected, result)
+	}
+}
+
+func TestFeltPowZeroExponent(t *testing.T) {
+	result := lambdaworks.FeltFromUint64(17).Pow(lambdaworks.FeltZero())
+	if result != lambdaworks.FeltOne() {
+		t.Errorf("TestFeltPowZeroExponent failed. Expected: 1, Got: %v", result)
+	}
+}
+
+func TestFeltPyDivModNearPrime(t *testing.T) {
+	// divmod(PRIME - 2, 7) in python, while field division would return (PRIME - 2) * 7^-1
+	a := lambdaworks.FeltFromDecString("-2")
+	b := lambdaworks.FeltFromUint64(7)
+	quotient, remainder := a.PyDivMod(b)
+	expectedQuotient := lambdaworks.FeltFromDecString("516928969809447316242474683299295729374729602190228099996156008019410288639")
+	if quotient != expectedQuotient || remainder != lambdaworks.FeltFromUint64(6) {
+		t.Errorf("TestFeltPyDivModNearPrime failed. Expected: (%v, 6), Got: (%v, %v)", expectedQuotient, quotient, remainder)
+	}
+	if quotient == a.Div(b) {
+		t.Errorf("TestFeltPyDivModNearPrime failed. Quotient should differ from field division")
+	}
+}
+
+func TestFeltPyDivModSmallValues(t *testing.T) {
+	quotient, remainder := lambdaworks.FeltFromUint64(7).PyDivMod(lambdaworks.FeltFromUint64(2))
+	if quotient != lambdaworks.FeltFromUint64(3) || remainder != lambdaworks.FeltOne() {
+		t.Errorf("TestFeltPyDivModSmallValues failed. Expected: (3, 1), Got: (%v, %v)", quotient, remainder)
+	}
+}
+
+func TestToStarkCurveScalar(t *testing.T) {
+	if scalar := lambdaworks.FeltFromUint64(12345).ToStarkCurveScalar(); scalar.Cmp(big.NewInt(12345)) != 0 {
+		t.Errorf("TestToStarkCurveScalar failed. Expected: 12345, Got: %v", scalar)
+	}
+	// PRIME - 1 is larger than the curve's order, so it gets reduced
+	order, _ := new(big.Int).SetString(lambdaworks.STARK_CURVE_ORDER_HEX, 0)
+	primeMinusOne := lambdaworks.FeltFromDecString("-1")
+	expected := new(big.Int).Sub(primeMinusOne.ToBigInt(), order)
+	if scalar := primeMinusOne.ToStarkCurveScalar(); scalar.Cmp(expected) != 0 {
+		t.Errorf("TestToStarkCurveScalar failed. Expected: %v, Got: %v", expected, scalar)
+	}
+}
+
+func TestFeltFromStarkCurveField(t *testing.T) {
+	value, _ := new(big.Int).SetString("0x1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca", 0)
+	felt := lambdaworks.FeltFromStarkCurveField(value)
+	if felt.ToBigInt().Cmp(value) != 0 {
+		t.Errorf("TestFeltFromStarkCurveField failed. Expected: %v, Got: %v", value, felt)
+	}
+}
+
+func TestFeltShr(t *testing.T) {
+	result := lambdaworks.FeltFromUint64(0b1011).Shr(2)
+	if result != lambdaworks.FeltFromUint64(0b10) {
+		t.Errorf("TestFeltShr failed. Expected: 2, Got: %v", result)
+	}
+	if !lambdaworks.FeltFromDecString("-1").Shr(252).IsZero() {
+		t.Errorf("TestFeltShr failed. Shifting out every bit should give zero")
+	}
+}
+
+func TestAddSliceAndMulSlice(t *testing.T) {
+	a := []lambdaworks.Felt{lambdaworks.FeltFromUint64(2), lambdaworks.FeltFromDecString("-1"), lambdaworks.FeltZero()}
+	b := []lambdaworks.Felt{lambdaworks.FeltFromUint64(3), lambdaworks.FeltFromUint64(2), lambdaworks.FeltFromUint64(7)}
+	sums, err := lambdaworks.AddSlice(a, b)
+	if err != nil {
+		t.Errorf("AddSlice failed with error: %s", err)
+		return
+	}
+	products, err := lambdaworks.MulSlice(a, b)
+	if err != nil {
+		t.Errorf("MulSlice failed with error: %s", err)
+		return
+	}
+	for i := range a {
+		if sums[i] != a[i].Add(b[i]) {
+			t.Errorf("Wrong sum at position %d. Expected %v, got %v", i, a[i].Add(b[i]), sums[i])
+		}
+		if products[i] != a[i].Mul(b[i]) {
+			t.Errorf("Wrong product at position %d. Expected %v, got %v", i, a[i].Mul(b[i]), products[i])
+		}
+	}
+}
+
+func TestAddSliceEmpty(t *testing.T) {
+	sums, err := lambdaworks.AddSlice(nil, []lambdaworks.Felt{})
+	if err != nil || len(sums) != 0 {
+		t.Errorf("Expected an empty result, got %v, %v", sums, err)
+	}
+}
+
+func TestMulSliceLengthMismatch(t *testing.T) {
+	_, err := lambdaworks.MulSlice([]lambdaworks.Felt{lambdaworks.FeltOne()}, nil)
+	if !errors.Is(err, lambdaworks.ErrSliceLengthMismatch) {
+		t.Errorf("Expected ErrSliceLengthMismatch, got %v", err)
+	}
+}
+
+func mulBenchmarkFelts() ([]lambdaworks.Felt, []lambdaworks.Felt) {
+	a := make([]lambdaworks.Felt, 10000)
+	b := make([]lambdaworks.Felt, 10000)
+	for i := range a {
+		a[i] = lambdaworks.FeltFromUint64(uint64(i) + 1).Shl(200)
+		b[i] = lambdaworks.FeltFromUint64(uint64(i) * 7).Shl(180)
+	}
+	return a, b
+}
+
+func BenchmarkMulSliceBatched(b *testing.B) {
+	x, y := mulBenchmarkFelts()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lambdaworks.MulSlice(x, y)
+	}
+}
+
+func BenchmarkMulSliceLooped(b *testing.B) {
+	x, y := mulBenchmarkFelts()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result := make([]lambdaworks.Felt, len(x))
+		for j := range x {
+			result[j] = x[j].Mul(y[j])
+		}
+	}
+}
+
+func TestFeltFromBytesSliceShort(t *testing.T) {
+	bytes := make([]byte, 16)
+	bytes[0] = 0x01
+	bytes[15] = 0x02
+	le, err := lambdaworks.FeltFromLeBytesSlice(bytes)
+	if err != nil {
+		t.Errorf("FeltFromLeBytesSlice failed with error: %s", err)
+		return
+	}
+	expectedLe := lambdaworks.FeltFromHex("0x02000000000000000000000000000001")
+	if le != expectedLe {
+		t.Errorf("Wrong little-endian felt. Expected %v, got %v", expectedLe, le)
+	}
+	be, err := lambdaworks.FeltFromBeBytesSlice(bytes)
+	if err != nil {
+		t.Errorf("FeltFromBeBytesSlice failed with error: %s", err)
+		return
+	}
+	expectedBe := lambdaworks.FeltFromHex("0x01000000000000000000000000000002")
+	if be != expectedBe {
+		t.Errorf("Wrong big-endian felt. Expected %v, got %v", expectedBe, be)
+	}
+}
+
+func TestFeltFromBytesSliceTooLong(t *testing.T) {
+	bytes := make([]byte, 33)
+	if _, err := lambdaworks.FeltFromLeBytesSlice(bytes); !errors.Is(err, lambdaworks.ErrFeltBytesTooLong) {
+		t.Errorf("Expected ErrFeltBytesTooLong, got %v", err)
+	}
+	if _, err := lambdaworks.FeltFromBeBytesSlice(bytes); !errors.Is(err, lambdaworks.ErrFeltBytesTooLong) {
+		t.Errorf("Expected ErrFeltBytesTooLong, got %v", err)
+	}
+}
+
+func TestFeltFromBytesSliceExceedsPrime(t *testing.T) {
+	// PRIME in big-endian
+	prime := lambdaworks.FeltFromDecString("-1").ToBeBytes()
+	prime[31] += 1
+	if _, err := lambdaworks.FeltFromBeBytesSlice(prime[:]); !errors.Is(err, lambdaworks.ErrFeltExceedsPrime) {
+		t.Errorf("Expected ErrFeltExceedsPrime, got %v", err)
+	}
+	primeMinusOne, err := lambdaworks.FeltFromBeBytesSlice(lambdaworks.FeltFromDecString("-1").ToBeBytes()[:])
+	if err != nil || primeMinusOne != lambdaworks.FeltFromDecString("-1") {
+		t.Errorf("Expected PRIME - 1, got %v, %v", primeMinusOne, err)
+	}
+}
@@ -55,6 +55,12 @@ void mul(felt_t a, felt_t b, felt_t result);
 /* Writes the result variable with a / b. */
 void lw_div(felt_t a, felt_t b, felt_t result);
 
+/* Writes result[i] with a[i] + b[i] for each of the len felts of the arrays. */
+void add_slice(felt_t *a, felt_t *b, felt_t *result, size_t len);
+
+/* Writes result[i] with a[i] * b[i] for each of the len felts of the arrays. */
+void mul_slice(felt_t *a, felt_t *b, felt_t *result, size_t len);
+
 /* Returns the minimum number of bits needed to represent the felt */
 limb_t bits(felt_t a);
 
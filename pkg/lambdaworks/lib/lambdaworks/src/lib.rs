@@ -152,6 +152,28 @@ pub extern "C" fn lw_div(a: Limbs, b: Limbs, result: Limbs) {
     felt_to_limbs(limbs_to_felt(a) / limbs_to_felt(b), result)
 }
 
+// Writes op(a[i], b[i]) into result[i] for each of the len felts stored contiguously in a, b and result,
+// so that operating over an array of felts takes a single FFI call.
+fn slice_op(a: Limbs, b: Limbs, result: Limbs, len: usize, op: fn(Felt, Felt) -> Felt) {
+    for i in 0..len {
+        let offset = (4 * i) as isize;
+        unsafe {
+            let value = op(limbs_to_felt(a.offset(offset)), limbs_to_felt(b.offset(offset)));
+            felt_to_limbs(value, result.offset(offset));
+        }
+    }
+}
+
+#[no_mangle]
+pub extern "C" fn add_slice(a: Limbs, b: Limbs, result: Limbs, len: usize) {
+    slice_op(a, b, result, len, |x, y| x + y)
+}
+
+#[no_mangle]
+pub extern "C" fn mul_slice(a: Limbs, b: Limbs, result: Limbs, len: usize) {
+    slice_op(a, b, result, len, |x, y| x * y)
+}
+
 #[no_mangle]
 pub extern "C" fn bits(limbs: Limbs) -> u64 {
     unsafe {
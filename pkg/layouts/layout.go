@@ -1,9 +1,14 @@
 package layouts
 
 import (
+	"fmt"
+
 	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/pkg/errors"
 )
 
+var ErrBuiltinRatioMismatch = errors.New("Builtin ratio doesn't match the layout")
+
 // Representation of a cairo layout.
 // Stores the layout name and the particular builtin instances and
 // their configuration for it.
@@ -65,3 +70,44 @@ func NewAllCairoLayout() CairoLayout {
 		DilutedPoolInstance:  DefaultDilutedPoolInstance(),
 	}
 }
+
+// Returns a copy of the layout using a diluted pool with the given parameters instead of its own,
+// which allows experimenting with custom layouts
+func (l CairoLayout) WithDilutedPool(spacing uint, nBits uint, unitsPerStep uint) CairoLayout {
+	l.DilutedPoolInstance = &DilutedPoolInstanceDef{UnitsPerStep: unitsPerStep, Spacing: spacing, NBits: nBits}
+	return l
+}
+
+// Returns the standard layout with the given name, or false if there is no such layout
+func getStandardLayout(name string) (CairoLayout, bool) {
+	switch name {
+	case "plain":
+		return NewPlainLayout(), true
+	case "small":
+		return NewSmallLayout(), true
+	case "all_cairo":
+		return NewAllCairoLayout(), true
+	default:
+		return CairoLayout{}, false
+	}
+}
+
+// Checks that the ratio of each of the layout's builtins matches the one the standard layout
+// with the same name defines for it. Layouts with a non-standard name are not validated
+func (l *CairoLayout) ValidateBuiltinRatios() error {
+	standardLayout, ok := getStandardLayout(l.Name)
+	if !ok {
+		return nil
+	}
+	expectedRatios := make(map[string]uint, len(standardLayout.Builtins))
+	for _, builtin := range standardLayout.Builtins {
+		expectedRatios[builtin.Name()] = builtin.Ratio()
+	}
+	for _, builtin := range l.Builtins {
+		expectedRatio, ok := expectedRatios[builtin.Name()]
+		if ok && builtin.Ratio() != expectedRatio {
+			return fmt.Errorf("%w, layout: %s, builtin: %s, expected ratio: %d, got: %d", ErrBuiltinRatioMismatch, l.Name, builtin.Name(), expectedRatio, builtin.Ratio())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+)
+
+type CasmEntryPoint struct {
+	Selector string   `json:"selector"`
+	Offset   uint     `json:"offset"`
+	Builtins []string `json:"builtins"`
+}
+
+type CasmEntryPointsByType struct {
+	External    []CasmEntryPoint `json:"EXTERNAL"`
+	L1Handler   []CasmEntryPoint `json:"L1_HANDLER"`
+	Constructor []CasmEntryPoint `json:"CONSTRUCTOR"`
+}
+
+// A compiled Cairo 1 contract class, as output by starknet-sierra-compile
+type CasmContractClass struct {
+	Prime           string   `json:"prime"`
+	CompilerVersion string   `json:"compiler_version"`
+	Bytecode        []string `json:"bytecode"`
+	// Hints of the bytecode, keyed by offset. Can be deserialized with vm.DeserializeHints
+	Hints             json.RawMessage       `json:"hints"`
+	EntryPointsByType CasmEntryPointsByType `json:"entry_points_by_type"`
+}
+
+func ParseCasm(jsonPath string) (CasmContractClass, error) {
+	byteValue, err := os.ReadFile(jsonPath)
+	if err != nil {
+		return CasmContractClass{}, ParserError(err)
+	}
+
+	var casm CasmContractClass
+	err = json.Unmarshal(byteValue, &casm)
+	if err != nil {
+		return CasmContractClass{}, ParserError(err)
+	}
+
+	return casm, nil
+}
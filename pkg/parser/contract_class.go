@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// An entry point of a Cairo 0 contract class, with its selector and offset as hex strings
+type ContractEntryPoint struct {
+	Selector string `json:"selector"`
+	Offset   string `json:"offset"`
+}
+
+type ContractEntryPointsByType struct {
+	External    []ContractEntryPoint `json:"EXTERNAL"`
+	L1Handler   []ContractEntryPoint `json:"L1_HANDLER"`
+	Constructor []ContractEntryPoint `json:"CONSTRUCTOR"`
+}
+
+// A compiled Cairo 0 contract class, as output by starknet-compile-deprecated
+type ContractClassJson struct {
+	Abi               json.RawMessage           `json:"abi"`
+	EntryPointsByType ContractEntryPointsByType `json:"entry_points_by_type"`
+	Program           CompiledJson              `json:"program"`
+}
+
+func ParseContractClass(jsonPath string) (ContractClassJson, error) {
+	byteValue, err := os.ReadFile(jsonPath)
+	if err != nil {
+		return ContractClassJson{}, ParserError(err)
+	}
+
+	var contractClass ContractClassJson
+	err = json.Unmarshal(byteValue, &contractClass)
+	if err != nil {
+		return ContractClassJson{}, ParserError(err)
+	}
+
+	return contractClass, nil
+}
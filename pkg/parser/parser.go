@@ -71,8 +71,18 @@ type HintParams struct {
 	FlowTrackingData FlowTrackingData `json:"flow_tracking_data"`
 }
 
+// An attribute set with `with_attr` over a range of pcs, such as an error message
+type Attribute struct {
+	Name             string            `json:"name"`
+	StartPc          uint              `json:"start_pc"`
+	EndPc            uint              `json:"end_pc"`
+	Value            string            `json:"value"`
+	AccessibleScopes []string          `json:"accessible_scopes"`
+	FlowTrackingData *FlowTrackingData `json:"flow_tracking_data"`
+}
+
 type CompiledJson struct {
-	Attributes       []string              `json:"attributes"`
+	Attributes       []Attribute           `json:"attributes"`
 	Builtins         []string              `json:"builtins"`
 	CompilerVersion  string                `json:"compiler_version"`
 	Data             []string              `json:"data"`
@@ -0,0 +1,176 @@
+package runners
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/pkg/errors"
+)
+
+const (
+	TRACE_FILE_NAME             = "trace.bin"
+	MEMORY_FILE_NAME            = "memory.bin"
+	AIR_PUBLIC_INPUT_FILE_NAME  = "air_public_input.json"
+	AIR_PRIVATE_INPUT_FILE_NAME = "air_private_input.json"
+)
+
+type MemorySegmentAddresses struct {
+	BeginAddr uint `json:"begin_addr"`
+	StopPtr   uint `json:"stop_ptr"`
+}
+
+type PublicMemoryEntry struct {
+	Address uint   `json:"address"`
+	Value   string `json:"value"`
+	Page    uint   `json:"page"`
+}
+
+// Public input of the Cairo AIR, in the format expected by the prover
+type AirPublicInput struct {
+	Layout         string                            `json:"layout"`
+	RcMin          uint                              `json:"rc_min"`
+	RcMax          uint                              `json:"rc_max"`
+	NSteps         uint                              `json:"n_steps"`
+	MemorySegments map[string]MemorySegmentAddresses `json:"memory_segments"`
+	PublicMemory   []PublicMemoryEntry               `json:"public_memory"`
+}
+
+// Private input of the Cairo AIR, pointing to the trace and memory files
+type AirPrivateInput struct {
+	TracePath  string `json:"trace_path"`
+	MemoryPath string `json:"memory_path"`
+}
+
+// Builds the air public input of a proof mode run.
+// Segments have to be finalized and the vm relocated beforehand
+func (r *CairoRunner) GetAirPublicInput(virtualMachine *vm.VirtualMachine) (*AirPublicInput, error) {
+	if !r.ProofMode {
+		return nil, errors.New("The air public input can only be built for proof mode runs")
+	}
+	if virtualMachine.RelocatedMemory == nil {
+		return nil, errors.New("Called GetAirPublicInput before the vm was relocated")
+	}
+	publicAddresses, err := r.GetPublicMemoryAddresses(virtualMachine)
+	if err != nil {
+		return nil, err
+	}
+	relocationTable, err := virtualMachine.Segments.RelocateSegments()
+	if err != nil {
+		return nil, err
+	}
+
+	publicMemory := make([]PublicMemoryEntry, 0, len(publicAddresses))
+	for _, addr := range publicAddresses {
+		value, ok := virtualMachine.RelocatedMemory[addr]
+		if !ok {
+			return nil, errors.Errorf("Public memory address %d is missing from the relocated memory", addr)
+		}
+		publicMemory = append(publicMemory, PublicMemoryEntry{Address: addr, Value: "0x" + value.ToBigInt().Text(16)})
+	}
+
+	memorySegments := map[string]MemorySegmentAddresses{
+		"program": {
+			BeginAddr: r.ProgramBase.RelocateAddress(&relocationTable),
+			StopPtr:   virtualMachine.RunContext.Pc.RelocateAddress(&relocationTable),
+		},
+		"execution": {
+			BeginAddr: r.executionBase.RelocateAddress(&relocationTable),
+			StopPtr:   virtualMachine.RunContext.Ap.RelocateAddress(&relocationTable),
+		},
+	}
+	for _, builtin := range virtualMachine.BuiltinRunners {
+		stopPtr := builtin.GetStopPtr()
+		if stopPtr == nil {
+			return nil, builtins.NewErrNoStopPointer(builtin.Name())
+		}
+		base := builtin.Base()
+		memorySegments[builtin.Name()] = MemorySegmentAddresses{
+			BeginAddr: base.RelocateAddress(&relocationTable),
+			StopPtr:   relocationTable[base.SegmentIndex] + *stopPtr,
+		}
+	}
+
+	rcMin, rcMax := r.getPermRangeCheckLimits(virtualMachine)
+	return &AirPublicInput{
+		Layout:         r.Layout.Name,
+		RcMin:          rcMin,
+		RcMax:          rcMax,
+		NSteps:         uint(len(virtualMachine.RelocatedTrace)),
+		MemorySegments: memorySegments,
+		PublicMemory:   publicMemory,
+	}, nil
+}
+
+// Returns the range check limits of the run, taking into account both the instruction offsets and the builtins
+func (r *CairoRunner) getPermRangeCheckLimits(virtualMachine *vm.VirtualMachine) (uint, uint) {
+	var rcMin, rcMax *uint
+	if virtualMachine.RcLimitsMin != nil && virtualMachine.RcLimitsMax != nil {
+		rcMin, rcMax = new(uint), new(uint)
+		*rcMin, *rcMax = uint(*virtualMachine.RcLimitsMin), uint(*virtualMachine.RcLimitsMax)
+	}
+	for _, builtin := range virtualMachine.BuiltinRunners {
+		builtinMin, builtinMax := builtin.GetRangeCheckUsage(&virtualMachine.Segments.Memory)
+		if builtinMin == nil || builtinMax == nil {
+			continue
+		}
+		if rcMin == nil || *builtinMin < *rcMin {
+			rcMin = builtinMin
+		}
+		if rcMax == nil || *builtinMax > *rcMax {
+			rcMax = builtinMax
+		}
+	}
+	if rcMin == nil || rcMax == nil {
+		return 0, 0
+	}
+	return *rcMin, *rcMax
+}
+
+// Writes the artifacts needed by the prover after a proof mode run into dir:
+// the encoded trace and memory, and the air public and private inputs.
+// Segments have to be finalized and the vm relocated beforehand
+func (r *CairoRunner) WriteProverArtifacts(dir string, virtualMachine *vm.VirtualMachine) error {
+	publicInput, err := r.GetAirPublicInput(virtualMachine)
+	if err != nil {
+		return err
+	}
+
+	tracePath := filepath.Join(dir, TRACE_FILE_NAME)
+	traceFile, err := os.Create(tracePath)
+	if err != nil {
+		return err
+	}
+	defer traceFile.Close()
+	err = vm.WriteEncodedTrace(virtualMachine.RelocatedTrace, traceFile)
+	if err != nil {
+		return err
+	}
+
+	memoryPath := filepath.Join(dir, MEMORY_FILE_NAME)
+	memoryFile, err := os.Create(memoryPath)
+	if err != nil {
+		return err
+	}
+	defer memoryFile.Close()
+	err = vm.WriteEncodedMemory(virtualMachine.RelocatedMemory, memoryFile)
+	if err != nil {
+		return err
+	}
+
+	err = writeJsonFile(filepath.Join(dir, AIR_PUBLIC_INPUT_FILE_NAME), publicInput)
+	if err != nil {
+		return err
+	}
+	return writeJsonFile(filepath.Join(dir, AIR_PRIVATE_INPUT_FILE_NAME), AirPrivateInput{TracePath: tracePath, MemoryPath: memoryPath})
+}
+
+func writeJsonFile(path string, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
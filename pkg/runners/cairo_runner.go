@@ -1,11 +1,14 @@
 package runners
 
 import (
+	"context"
 	"fmt"
+	"sort"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	"github.com/lambdaclass/cairo-vm.go/pkg/layouts"
+	"github.com/lambdaclass/cairo-vm.go/pkg/starknet"
 	"github.com/lambdaclass/cairo-vm.go/pkg/types"
 	"github.com/lambdaclass/cairo-vm.go/pkg/utils"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
@@ -14,6 +17,11 @@ import (
 )
 
 var ErrRunnerCalledTwice = errors.New("Cairo Runner was called twice")
+var ErrDryRunMaxStepsExceeded = errors.New("Dry run exceeded the maximum amount of steps")
+var ErrResetMidRun = errors.New("Cannot reset a Cairo Runner in the middle of a run")
+
+// Amount of steps executed by RunUntilPCContext between context checks
+const CONTEXT_CHECK_INTERVAL = 1024
 
 type CairoRunner struct {
 	Program               vm.Program
@@ -31,6 +39,17 @@ type CairoRunner struct {
 	execScopes            types.ExecutionScopes
 	ExecutionPublicMemory *[]uint
 	SegmentsFinalized     bool
+	// Compiled hint data of the program, built on the first run call and reused by the following ones
+	hintDataMap map[uint][]any
+	// Constants of the program, extracted on the first run call and reused by the following ones
+	constants map[string]lambdaworks.Felt
+	// When set outside of proof mode, builtins used by the program but missing from the layout are added
+	// to the run instead of failing, which allows running programs under any layout for testing purposes
+	AllowMissingBuiltins bool
+	// Entry points of the contract class the runner was created for, see NewCairoRunnerForContractClass
+	entryPoints *starknet.EntryPointsByType
+	// Hint processor that compiled hintDataMap, the hint data is rebuilt when another processor is used
+	hintDataProcessor vm.HintProcessor
 }
 
 func NewCairoRunner(program vm.Program, layoutName string, proofMode bool) (*CairoRunner, error) {
@@ -40,9 +59,9 @@ func NewCairoRunner(program vm.Program, layoutName string, proofMode bool) (*Cai
 		main_offset = uint(mainIdentifier.PC)
 	}
 
-	err := utils.CheckBuiltinsSubsequence(program.Builtins)
+	err := program.ValidateBuiltins()
 	if err != nil {
-		return nil, errors.New(err.Error())
+		return nil, err
 	}
 
 	var layout layouts.CairoLayout
@@ -74,7 +93,10 @@ func (r *CairoRunner) Initialize() (memory.Relocatable, error) {
 	if err != nil {
 		return memory.Relocatable{}, errors.New(err.Error())
 	}
-	r.initializeSegments()
+	err = r.initializeSegments()
+	if err != nil {
+		return memory.Relocatable{}, err
+	}
 	end, err := r.initializeMainEntrypoint()
 	if err == nil {
 		err = r.initializeVM()
@@ -82,9 +104,48 @@ func (r *CairoRunner) Initialize() (memory.Relocatable, error) {
 	return end, err
 }
 
+// Performs the initialization step like Initialize, but sets up a call to the function at the entrypoint offset
+// with the given stack, instead of a call to main with the builtins' initial stack. The return fp and the end
+// pointer are appended to a copy of the stack. Returns the end pointer (pc upon which execution should stop).
+// The proof mode entrypoint isn't used, the function is always called directly
+func (r *CairoRunner) InitializeWithStack(entrypoint uint, stack []memory.MaybeRelocatable) (memory.Relocatable, error) {
+	if entrypoint >= uint(len(r.Program.Data)) {
+		return memory.Relocatable{}, errors.Errorf("Entrypoint %d is out of the program's bounds", entrypoint)
+	}
+	err := r.initializeBuiltins()
+	if err != nil {
+		return memory.Relocatable{}, err
+	}
+	err = r.initializeSegments()
+	if err != nil {
+		return memory.Relocatable{}, err
+	}
+	return r.initializeFunctionCall(entrypoint, stack)
+}
+
+// Sets up a call to the function at the entrypoint offset with the given stack and initializes the vm,
+// assuming the builtins and segments were already initialized. Returns the end pointer
+func (r *CairoRunner) initializeFunctionCall(entrypoint uint, stack []memory.MaybeRelocatable) (memory.Relocatable, error) {
+	returnFp, err := r.Vm.Segments.AddSegment()
+	if err != nil {
+		return memory.Relocatable{}, err
+	}
+	functionStack := append(make([]memory.MaybeRelocatable, 0, len(stack)+2), stack...)
+	end, err := r.initializeFunctionEntrypoint(entrypoint, &functionStack, returnFp)
+	if err == nil {
+		err = r.initializeVM()
+	}
+	return end, err
+}
+
 // Initializes builtin runners in accordance to the specified layout and
 // the builtins present in the running program.
 func (r *CairoRunner) initializeBuiltins() error {
+	err := r.Layout.ValidateBuiltinRatios()
+	if err != nil {
+		return err
+	}
+
 	var builtinRunners []builtins.BuiltinRunner
 	programBuiltins := map[string]struct{}{}
 	for _, builtin := range r.Program.Builtins {
@@ -92,6 +153,9 @@ func (r *CairoRunner) initializeBuiltins() error {
 	}
 
 	for _, layoutBuiltin := range r.Layout.Builtins {
+		// The layout's builtins are only templates, each run gets its own copy so that its state
+		// (segment base, stop pointer, caches) doesn't leak into other runs of the same layout
+		layoutBuiltin = layoutBuiltin.Clone()
 		_, included := programBuiltins[layoutBuiltin.Name()]
 		if included {
 			delete(programBuiltins, layoutBuiltin.Name())
@@ -104,7 +168,22 @@ func (r *CairoRunner) initializeBuiltins() error {
 	}
 
 	if len(programBuiltins) != 0 {
-		return errors.Errorf("Builtin(s) %v not present in layout %s", programBuiltins, r.Layout.Name)
+		if !r.AllowMissingBuiltins || r.ProofMode {
+			return errors.Errorf("Builtin(s) %v not present in layout %s", programBuiltins, r.Layout.Name)
+		}
+		missingBuiltins, err := newMissingBuiltinRunners(programBuiltins)
+		if err != nil {
+			return err
+		}
+		builtinRunners = append(builtinRunners, missingBuiltins...)
+		// The builtins' initial stack has to follow the order of the program's builtins
+		builtinsOrder := make(map[string]int, len(r.Program.Builtins))
+		for i, name := range r.Program.Builtins {
+			builtinsOrder[name] = i
+		}
+		sort.SliceStable(builtinRunners, func(i, j int) bool {
+			return builtinsOrder[builtinRunners[i].Name()] < builtinsOrder[builtinRunners[j].Name()]
+		})
 	}
 
 	r.Vm.BuiltinRunners = builtinRunners
@@ -113,15 +192,42 @@ func (r *CairoRunner) initializeBuiltins() error {
 }
 
 // Creates program, execution and builtin segments
-func (r *CairoRunner) initializeSegments() {
-	// Program Segment
-	r.ProgramBase = r.Vm.Segments.AddSegment()
+// Creates the runners of builtins that aren't part of the layout, using the configuration the all_cairo layout
+// has for them. Fails if a builtin isn't supported by any layout
+func newMissingBuiltinRunners(missingBuiltins map[string]struct{}) ([]builtins.BuiltinRunner, error) {
+	var builtinRunners []builtins.BuiltinRunner
+	for _, builtin := range layouts.NewAllCairoLayout().Builtins {
+		if _, missing := missingBuiltins[builtin.Name()]; missing {
+			builtin.Include(true)
+			builtinRunners = append(builtinRunners, builtin)
+		}
+	}
+	if len(builtinRunners) != len(missingBuiltins) {
+		return nil, errors.Errorf("Builtin(s) %v are not supported", missingBuiltins)
+	}
+	return builtinRunners, nil
+}
+
+func (r *CairoRunner) initializeSegments() error {
+	var err error
+	// Program Segment, stored densely as it is filled contiguously with the program data
+	r.ProgramBase, err = r.Vm.Segments.AddSegmentWithCapacity(uint(len(r.Program.Data)))
+	if err != nil {
+		return err
+	}
 	// Execution Segment
-	r.executionBase = r.Vm.Segments.AddSegment()
+	r.executionBase, err = r.Vm.Segments.AddSegment()
+	if err != nil {
+		return err
+	}
 	// Builtin Segments
 	for i := range r.Vm.BuiltinRunners {
-		r.Vm.BuiltinRunners[i].InitializeSegments(&r.Vm.Segments)
+		err = r.Vm.BuiltinRunners[i].InitializeSegments(&r.Vm.Segments)
+		if err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 // Initializes the program segment & initial pc
@@ -133,6 +239,8 @@ func (r *CairoRunner) initializeState(entrypoint uint, stack *[]memory.MaybeRelo
 	if err == nil {
 		_, err = r.Vm.Segments.LoadData(r.executionBase, stack)
 	}
+	// The program segment won't grow during execution
+	r.Vm.Segments.FreezeSegment(uint(r.ProgramBase.SegmentIndex), r.ProgramBase.Offset+uint(len(r.Program.Data)))
 	// Mark data segment as accessed
 	base := r.ProgramBase
 	var i uint
@@ -145,7 +253,10 @@ func (r *CairoRunner) initializeState(entrypoint uint, stack *[]memory.MaybeRelo
 // Initializes memory, initial register values & returns the end pointer (final pc) to run from a given pc offset
 // (entrypoint)
 func (r *CairoRunner) initializeFunctionEntrypoint(entrypoint uint, stack *[]memory.MaybeRelocatable, return_fp memory.Relocatable) (memory.Relocatable, error) {
-	end := r.Vm.Segments.AddSegment()
+	end, err := r.Vm.Segments.AddSegment()
+	if err != nil {
+		return memory.Relocatable{}, err
+	}
 	*stack = append(*stack, *memory.NewMaybeRelocatableRelocatable(return_fp), *memory.NewMaybeRelocatableRelocatable(end))
 	r.initialFp = r.executionBase
 	r.initialFp.Offset += uint(len(*stack))
@@ -188,7 +299,10 @@ func (r *CairoRunner) initializeMainEntrypoint() (memory.Relocatable, error) {
 		return memory.NewRelocatable(r.ProgramBase.SegmentIndex, r.ProgramBase.Offset+r.Program.End), nil
 	}
 
-	return_fp := r.Vm.Segments.AddSegment()
+	return_fp, err := r.Vm.Segments.AddSegment()
+	if err != nil {
+		return memory.Relocatable{}, err
+	}
 	return r.initializeFunctionEntrypoint(r.mainOffset, &stack, return_fp)
 }
 
@@ -222,21 +336,125 @@ func (r *CairoRunner) BuildHintDataMap(hintProcessor vm.HintProcessor) (map[uint
 	return hintDataMap, nil
 }
 
-func (r *CairoRunner) RunUntilPC(end memory.Relocatable, hintProcessor vm.HintProcessor) error {
+// Returns the program's hint data as compiled by hintProcessor, compiling it on the first call.
+// As the program doesn't change during the runner's lifetime, the result is cached and reused by every run call
+// with the same processor (the proof mode padding loop calls RunForSteps many times). Calls with another
+// processor compile the hint data again
+func (r *CairoRunner) getHintDataMap(hintProcessor vm.HintProcessor) (map[uint][]any, error) {
+	if r.hintDataMap != nil && r.hintDataProcessor == hintProcessor {
+		return r.hintDataMap, nil
+	}
 	hintDataMap, err := r.BuildHintDataMap(hintProcessor)
 	if err != nil {
+		return nil, err
+	}
+	r.hintDataMap = hintDataMap
+	r.hintDataProcessor = hintProcessor
+	return hintDataMap, nil
+}
+
+// Returns the program's constants, extracting them on the first call and caching them like the hint data
+func (r *CairoRunner) getConstants() map[string]lambdaworks.Felt {
+	if r.constants == nil {
+		r.constants = r.Program.ExtractConstants()
+	}
+	return r.constants
+}
+
+// Wraps an error raised while executing the instruction at pc with its source location, producing messages of the form
+// `error at file.cairo:line: cause`. The error is returned unchanged if the program has no debug info for pc
+func (r *CairoRunner) wrapErrorWithLocation(err error, pc memory.Relocatable) error {
+	if pc.SegmentIndex != r.ProgramBase.SegmentIndex || pc.Offset < r.ProgramBase.Offset {
 		return err
 	}
-	constants := r.Program.ExtractConstants()
-	for r.Vm.RunContext.Pc != end {
+	location, ok := r.Program.GetLocationForPc(pc.Offset - r.ProgramBase.Offset)
+	if !ok {
+		return err
+	}
+	return errors.Wrapf(err, "error at %s:%d", location.Inst.InputFile["filename"], location.Inst.StartLine)
+}
+
+func (r *CairoRunner) RunUntilPC(end memory.Relocatable, hintProcessor vm.HintProcessor) error {
+	return r.RunUntilPCContext(context.Background(), end, hintProcessor)
+}
+
+// Runs the program until the pc reaches end, or until ctx is cancelled.
+// The context is checked every CONTEXT_CHECK_INTERVAL steps, if it was cancelled, its error is returned
+func (r *CairoRunner) RunUntilPCContext(ctx context.Context, end memory.Relocatable, hintProcessor vm.HintProcessor) error {
+	return r.runUntilPC(ctx, end, hintProcessor, noStepLimit)
+}
+
+// Value of maxSteps for runUntilPC that doesn't limit the amount of steps
+const noStepLimit = ^uint(0)
+
+// Runs the program until the pc reaches end, like RunUntilPCContext.
+// Fails with ErrDryRunMaxStepsExceeded if the end isn't reached within maxSteps steps
+func (r *CairoRunner) runUntilPC(ctx context.Context, end memory.Relocatable, hintProcessor vm.HintProcessor, maxSteps uint) error {
+	hintDataMap, err := r.getHintDataMap(hintProcessor)
+	if err != nil {
+		return err
+	}
+	constants := r.getConstants()
+	for steps := uint(0); r.Vm.RunContext.Pc != end; steps++ {
+		if steps >= maxSteps {
+			return ErrDryRunMaxStepsExceeded
+		}
+		if steps%CONTEXT_CHECK_INTERVAL == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
 		err := r.Vm.Step(hintProcessor, &hintDataMap, &constants, &r.execScopes)
 		if err != nil {
-			return err
+			return r.wrapErrorWithLocation(err, r.Vm.RunContext.Pc)
 		}
 	}
 	return nil
 }
 
+// Estimates the amount of steps needed to run the program until its end, without producing a trace.
+// The run is performed on a freshly initialized runner with the same configuration (see newRunnerWithSameConfig),
+// so the receiver is left untouched and can be used for a real run afterwards.
+// Returns ErrDryRunMaxStepsExceeded (along with the steps executed) if the end isn't reached within maxSteps
+func (r *CairoRunner) DryRunStepCount(hintProcessor vm.HintProcessor, maxSteps uint) (uint, error) {
+	dryRunner := r.newRunnerWithSameConfig()
+	dryRunner.Vm.TraceDisabled = true
+	end, err := dryRunner.Initialize()
+	if err != nil {
+		return 0, err
+	}
+	err = dryRunner.runUntilPC(context.Background(), end, hintProcessor, maxSteps)
+	return dryRunner.Vm.CurrentStep, err
+}
+
+// Returns a new runner with the same configuration as r: program, layout (including customizations such as
+// WithDilutedPool), entrypoint, proof mode, missing builtins allowance, contract entry points and the VM
+// configuration (see VirtualMachine.NewWithSameConfig).
+// None of r's run state is carried over, as the builtins are copied from the layout on initialization
+func (r *CairoRunner) newRunnerWithSameConfig() *CairoRunner {
+	return &CairoRunner{
+		Program:              r.Program,
+		Vm:                   *r.Vm.NewWithSameConfig(),
+		mainOffset:           r.mainOffset,
+		ProofMode:            r.ProofMode,
+		Layout:               r.Layout,
+		execScopes:           *types.NewExecutionScopes(),
+		AllowMissingBuiltins: r.AllowMissingBuiltins,
+		entryPoints:          r.entryPoints,
+	}
+}
+
+// Resets the runner so that the same program can be run again, as if it had just been created.
+// Memory segments, registers, builtins and the run state are cleared, while the configuration is kept
+// (see newRunnerWithSameConfig). Fails with ErrResetMidRun if a run has started but not ended yet
+func (r *CairoRunner) Reset() error {
+	if r.Vm.CurrentStep != 0 && !r.RunEnded {
+		return ErrResetMidRun
+	}
+	*r = *r.newRunnerWithSameConfig()
+	return nil
+}
+
 func (runner *CairoRunner) EndRun(disableTracePadding bool, disableFinalizeAll bool, vm *vm.VirtualMachine, hintProcessor vm.HintProcessor) error {
 	if runner.RunEnded {
 		return ErrRunnerCalledTwice
@@ -263,7 +481,7 @@ func (runner *CairoRunner) EndRun(disableTracePadding bool, disableFinalizeAll b
 
 		for true {
 			err := runner.CheckUsedCells(vm)
-			if errors.Unwrap(err) == memory.ErrInsufficientAllocatedCells {
+			if errors.Is(err, memory.ErrInsufficientAllocatedCells) {
 			} else if err != nil {
 				return err
 			} else {
@@ -318,10 +536,12 @@ func (r *CairoRunner) FinalizeSegments(virtualMachine vm.VirtualMachine) error {
 
 	virtualMachine.Segments.Finalize(nil, uint(execBase.SegmentIndex), &publicMemory)
 	for _, builtin := range virtualMachine.BuiltinRunners {
-		_, size, err := builtin.GetUsedCellsAndAllocatedSizes(&virtualMachine.Segments, virtualMachine.CurrentStep)
+		used, size, err := builtin.GetUsedCellsAndAllocatedSizes(&virtualMachine.Segments, virtualMachine.CurrentStep)
 		if err != nil {
 			return err
 		}
+		// Builtin segments won't grow once the run has ended
+		virtualMachine.Segments.FreezeSegment(uint(builtin.Base().SegmentIndex), used)
 
 		if builtin.Name() == builtins.OUTPUT_BUILTIN_NAME {
 			var publicMemory []uint
@@ -339,6 +559,96 @@ func (r *CairoRunner) FinalizeSegments(virtualMachine vm.VirtualMachine) error {
 	return nil
 }
 
+// Returns the relocated addresses of the public memory (program, execution and output pages),
+// which are the addresses referenced by the air public input.
+// Segments have to be finalized beforehand so that the public memory offsets and segment sizes are known
+func (r *CairoRunner) GetPublicMemoryAddresses(virtualMachine *vm.VirtualMachine) ([]uint, error) {
+	if !r.SegmentsFinalized {
+		return nil, errors.New("Called GetPublicMemoryAddresses before segments were finalized")
+	}
+	virtualMachine.Segments.ComputeEffectiveSizes()
+	relocationTable, err := virtualMachine.Segments.RelocateSegments()
+	if err != nil {
+		return nil, err
+	}
+	return virtualMachine.Segments.GetPublicMemoryAddresses(&relocationTable)
+}
+
+// Segment metadata of a builtin, as requested by external proving pipelines
+type SegmentInfo struct {
+	Name  string
+	Index uint
+	Size  uint
+	Ratio uint
+}
+
+// Returns the final pc, ap and fp registers of the run. Fails if the run hasn't ended yet
+func (r *CairoRunner) GetFinalState() (memory.Relocatable, memory.Relocatable, memory.Relocatable, error) {
+	if !r.RunEnded {
+		return memory.Relocatable{}, memory.Relocatable{}, memory.Relocatable{}, errors.New("Called GetFinalState before run had ended")
+	}
+	return r.Vm.RunContext.Pc, r.Vm.RunContext.Ap, r.Vm.RunContext.Fp, nil
+}
+
+// Returns the values written to the output builtin's segment formatted as decimal strings.
+// If signed is set, values are formatted as signed felts, so that PRIME - 1 is returned as -1.
+// Fails if the run hasn't ended yet, if the output builtin isn't present, or if the output has holes or relocatable values
+func (r *CairoRunner) GetOutputStrings(signed bool) ([]string, error) {
+	if !r.RunEnded {
+		return nil, errors.New("Called GetOutputStrings before run had ended")
+	}
+	outputBuiltin, err := r.Vm.GetBuiltinRunner(builtins.OUTPUT_BUILTIN_NAME)
+	if err != nil {
+		return nil, err
+	}
+	segmentIndex := (*outputBuiltin).Base().SegmentIndex
+	r.Vm.Segments.ComputeEffectiveSizes()
+	outputSize, err := r.Vm.Segments.GetSegmentUsedSize(uint(segmentIndex))
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.Vm.Segments.Memory.GetFeltRange(memory.NewRelocatable(segmentIndex, 0), outputSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]string, 0, outputSize)
+	for _, value := range values {
+		if signed {
+			output = append(output, value.ToSignedFeltString())
+		} else {
+			output = append(output, value.String())
+		}
+	}
+	return output, nil
+}
+
+// Returns the name, segment index, used size and ratio of each builtin included by the program,
+// in the order they appear in the vm. Fails if the run hasn't ended yet
+func (r *CairoRunner) GetBuiltinSegmentInfo() ([]SegmentInfo, error) {
+	if !r.RunEnded {
+		return nil, errors.New("Called GetBuiltinSegmentInfo before run had ended")
+	}
+	segmentsInfo := make([]SegmentInfo, 0, len(r.Program.Builtins))
+	for _, builtin := range r.Vm.BuiltinRunners {
+		if !builtin.IsIncluded() {
+			continue
+		}
+		used, _, err := builtin.GetUsedCellsAndAllocatedSizes(&r.Vm.Segments, r.Vm.CurrentStep)
+		if err != nil {
+			return nil, err
+		}
+		segmentsInfo = append(segmentsInfo, SegmentInfo{
+			Name:  builtin.Name(),
+			Index: uint(builtin.Base().SegmentIndex),
+			Size:  used,
+			Ratio: builtin.Ratio(),
+		})
+	}
+	return segmentsInfo, nil
+}
+
 func (r *CairoRunner) ReadReturnValues(virtualMachine *vm.VirtualMachine) error {
 	if !r.RunEnded {
 		return errors.New("Tried to read return values before run ended")
@@ -379,6 +689,26 @@ func (r *CairoRunner) ReadReturnValues(virtualMachine *vm.VirtualMachine) error
 
 }
 
+// Checks that the stop pointer of each builtin, set by ReadReturnValues, is consistent with the
+// amount of builtin instances used during the run (used_instances * cells_per_instance)
+func (runner *CairoRunner) CheckStopPointers(virtualMachine *vm.VirtualMachine) error {
+	for _, builtin := range virtualMachine.BuiltinRunners {
+		stopPtr := builtin.GetStopPtr()
+		if stopPtr == nil {
+			return builtins.NewErrNoStopPointer(builtin.Name())
+		}
+		usedInstances, err := builtin.GetUsedInstances(&virtualMachine.Segments)
+		if err != nil {
+			return err
+		}
+		used := usedInstances * builtin.CellsPerInstance()
+		if *stopPtr != used {
+			return builtins.NewErrInvalidStopPointer(builtin.Name(), used, memory.NewRelocatable(builtin.Base().SegmentIndex, *stopPtr))
+		}
+	}
+	return nil
+}
+
 func (runner *CairoRunner) CheckUsedCells(virtualMachine *vm.VirtualMachine) error {
 	for _, builtin := range virtualMachine.BuiltinRunners {
 		// I guess we call this just in case it errors out, even though later on we also call it?
@@ -437,20 +767,26 @@ func (runner *CairoRunner) CheckMemoryUsage(virtualMachine *vm.VirtualMachine) e
 	}
 
 	if unusedMemoryUnits < memoryAddressHoles {
-		return memory.InsufficientAllocatedCellsError(unusedMemoryUnits, memoryAddressHoles)
+		return memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_MEMORY_UNITS, Used: memoryAddressHoles, Allocated: unusedMemoryUnits}
 	}
 
 	return nil
 }
 
 func (runner *CairoRunner) GetMemoryHoles(virtualMachine *vm.VirtualMachine) (uint, error) {
-	return virtualMachine.Segments.GetMemoryHoles(uint(len(virtualMachine.BuiltinRunners)))
+	builtinSegments := make([]uint, 0, len(virtualMachine.BuiltinRunners))
+	for _, builtin := range virtualMachine.BuiltinRunners {
+		builtinSegments = append(builtinSegments, uint(builtin.Base().SegmentIndex))
+	}
+	return virtualMachine.Segments.GetMemoryHoles(builtinSegments)
 }
 
-func (runner *CairoRunner) CheckDilutedCheckUsage(virtualMachine *vm.VirtualMachine) error {
+// Returns the diluted units used by the builtins and the diluted units available for the steps executed so far,
+// as computed by CheckDilutedCheckUsage. Both are zero if the layout has no diluted pool
+func (runner *CairoRunner) GetDilutedUsage(virtualMachine *vm.VirtualMachine) (uint, uint, error) {
 	dilutedPoolInstance := runner.Layout.DilutedPoolInstance
 	if dilutedPoolInstance == nil {
-		return nil
+		return 0, 0, nil
 	}
 
 	var usedUnitsByBuiltins uint = 0
@@ -465,19 +801,34 @@ func (runner *CairoRunner) CheckDilutedCheckUsage(virtualMachine *vm.VirtualMach
 		multiplier, err := utils.SafeDiv(virtualMachine.CurrentStep, ratio)
 
 		if err != nil {
-			return err
+			return 0, 0, err
 		}
 
 		usedUnitsByBuiltins += usedUnits * multiplier
 	}
 
 	var dilutedUnits uint = dilutedPoolInstance.UnitsPerStep * virtualMachine.CurrentStep
+
+	return usedUnitsByBuiltins, dilutedUnits, nil
+}
+
+func (runner *CairoRunner) CheckDilutedCheckUsage(virtualMachine *vm.VirtualMachine) error {
+	dilutedPoolInstance := runner.Layout.DilutedPoolInstance
+	if dilutedPoolInstance == nil {
+		return nil
+	}
+
+	usedUnitsByBuiltins, dilutedUnits, err := runner.GetDilutedUsage(virtualMachine)
+	if err != nil {
+		return err
+	}
+
 	var unusedDilutedUnits uint = dilutedUnits - usedUnitsByBuiltins
 
 	var dilutedUsageUpperBound uint = 1 << dilutedPoolInstance.NBits
 
 	if unusedDilutedUnits < dilutedUsageUpperBound {
-		return memory.InsufficientAllocatedCellsError(unusedDilutedUnits, dilutedUsageUpperBound)
+		return memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_DILUTED_UNITS, Used: dilutedUsageUpperBound, Allocated: unusedDilutedUnits}
 	}
 
 	return nil
@@ -524,7 +875,7 @@ func (runner *CairoRunner) CheckRangeCheckUsage(virtualMachine *vm.VirtualMachin
 	unusedRcUnits := (runner.Layout.RcUnits-3)*virtualMachine.CurrentStep - uint(rcUnitsUsedByBuiltins)
 
 	if unusedRcUnits < (*rcMax - *rcMin) {
-		return memory.InsufficientAllocatedCellsError(unusedRcUnits, *rcMax-*rcMin)
+		return memory.InsufficientAllocatedCells{Kind: memory.INSUFFICIENT_CELLS_RANGE_CHECK_UNITS, Used: *rcMax - *rcMin, Allocated: unusedRcUnits}
 	}
 
 	return nil
@@ -532,11 +883,11 @@ func (runner *CairoRunner) CheckRangeCheckUsage(virtualMachine *vm.VirtualMachin
 
 // TODO: Add hint processor when it's done
 func (runner *CairoRunner) RunForSteps(steps uint, virtualMachine *vm.VirtualMachine, hintProcessor vm.HintProcessor) error {
-	hintDataMap, err := runner.BuildHintDataMap(hintProcessor)
+	hintDataMap, err := runner.getHintDataMap(hintProcessor)
 	if err != nil {
 		return err
 	}
-	constants := runner.Program.ExtractConstants()
+	constants := runner.getConstants()
 	var remainingSteps int
 	for remainingSteps = int(steps); remainingSteps > 0; remainingSteps-- {
 		if runner.finalPc != nil && *runner.finalPc == virtualMachine.RunContext.Pc {
@@ -545,13 +896,56 @@ func (runner *CairoRunner) RunForSteps(steps uint, virtualMachine *vm.VirtualMac
 
 		err := virtualMachine.Step(hintProcessor, &hintDataMap, &constants, &runner.execScopes)
 		if err != nil {
-			return err
+			return runner.wrapErrorWithLocation(err, virtualMachine.RunContext.Pc)
 		}
 	}
 
 	return nil
 }
 
+// Executes a single instruction, so that a debugger can inspect the vm between steps
+func (r *CairoRunner) StepOnce(hintProcessor vm.HintProcessor) error {
+	hintDataMap, err := r.getHintDataMap(hintProcessor)
+	if err != nil {
+		return err
+	}
+	constants := r.getConstants()
+	err = r.Vm.Step(hintProcessor, &hintDataMap, &constants, &r.execScopes)
+	if err != nil {
+		return r.wrapErrorWithLocation(err, r.Vm.RunContext.Pc)
+	}
+	return nil
+}
+
+// Runs the program until the pc reaches one of the breakpoints (given as offsets of the program segment) or the
+// program's end. At least one instruction is executed, so that the run can be resumed after stopping at a breakpoint.
+// Returns the offset of the breakpoint that was hit, or nil if the program ended
+func (r *CairoRunner) RunUntilBreakpoint(breakpoints map[uint]struct{}, hintProcessor vm.HintProcessor) (*uint, error) {
+	var end memory.Relocatable
+	if r.finalPc != nil {
+		end = *r.finalPc
+	} else if r.ProofMode {
+		end = r.ProgramBase.AddUint(r.Program.End)
+	} else {
+		return nil, errors.New("Called RunUntilBreakpoint before initializing the runner")
+	}
+	for r.Vm.RunContext.Pc != end {
+		err := r.StepOnce(hintProcessor)
+		if err != nil {
+			return nil, err
+		}
+		pc := r.Vm.RunContext.Pc
+		if pc.SegmentIndex != r.ProgramBase.SegmentIndex || pc.Offset < r.ProgramBase.Offset {
+			continue
+		}
+		offset := pc.Offset - r.ProgramBase.Offset
+		if _, ok := breakpoints[offset]; ok {
+			return &offset, nil
+		}
+	}
+	return nil, nil
+}
+
 // TODO: Add hint processor when it's done
 func (runner *CairoRunner) RunUntilSteps(steps uint, virtualMachine *vm.VirtualMachine, hintProcessor vm.HintProcessor) error {
 	return runner.RunForSteps(steps-virtualMachine.CurrentStep, virtualMachine, hintProcessor)
@@ -2,8 +2,13 @@ package runners_test
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"reflect"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
 	"github.com/lambdaclass/cairo-vm.go/pkg/hints"
@@ -11,6 +16,7 @@ import (
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
 	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/types"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/cairo_run"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
@@ -93,6 +99,39 @@ func TestInitializeRunnerNoBuiltinsNoProofModeEmptyProgram(t *testing.T) {
 	}
 }
 
+func TestInitializeRunnerProofModeStartEndLabels(t *testing.T) {
+	compiledProgram := parser.CompiledJson{
+		Prime: lambdaworks.CAIRO_PRIME_HEX,
+		Data:  []string{"0x1", "0x1", "0x1", "0x1", "0x1", "0x1", "0x1", "0x1", "0x1", "0x1"},
+		Identifiers: map[string]parser.Identifier{
+			"__start__":          {PC: 4, Type: "label"},
+			"__end__":            {PC: 8, Type: "label"},
+			"__main__.__start__": {PC: 0, Type: "label"},
+			"__main__.__end__":   {PC: 2, Type: "label"},
+		},
+	}
+	program, err := vm.DeserializeProgramJson(compiledProgram)
+	if err != nil {
+		t.Errorf("DeserializeProgramJson failed with error: %s", err)
+		return
+	}
+	runner, err := runners.NewCairoRunner(program, "plain", true)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+	}
+	if runner.Vm.RunContext.Pc != memory.NewRelocatable(0, 4) {
+		t.Errorf("Wrong Pc value, got %+v", runner.Vm.RunContext.Pc)
+	}
+	if end != memory.NewRelocatable(0, 8) {
+		t.Errorf("Wrong end ptr value, got %+v", end)
+	}
+}
+
 func TestInitializeRunnerNoBuiltinsNoProofModeNonEmptyProgram(t *testing.T) {
 	// Create a Program with one fake instruction
 	program_data := make([]memory.MaybeRelocatable, 1)
@@ -163,6 +202,33 @@ func TestInitializeRunnerNoBuiltinsNoProofModeNonEmptyProgram(t *testing.T) {
 	}
 }
 
+func TestInitializeRunnerProgramSegmentIsDense(t *testing.T) {
+	program_data := make([]memory.MaybeRelocatable, 3)
+	for i := range program_data {
+		program_data[i] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(i)))
+	}
+	program := vm.Program{Data: program_data, Identifiers: make(map[string]vm.Identifier, 0)}
+	runner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	// Cells of dense segments are not stored in the Data map
+	for addr := range runner.Vm.Segments.Memory.Data {
+		if addr.SegmentIndex == runner.ProgramBase.SegmentIndex {
+			t.Errorf("The program segment should use a dense backing, found %v in Data", addr)
+		}
+	}
+	if segment := runner.Vm.Segments.Memory.GetSegment(runner.ProgramBase.SegmentIndex); !reflect.DeepEqual(segment, program_data) {
+		t.Errorf("Wrong program segment. Expected %v, got %v", program_data, segment)
+	}
+}
+
 func TestInitializeRunnerWithRangeCheckValid(t *testing.T) {
 	t.Helper()
 	// Create a Program with one fake instruction
@@ -252,6 +318,24 @@ func TestInitializeRunnerWithRangeCheckInvalid(t *testing.T) {
 	}
 }
 
+func TestInitializeRunnerBuiltinRatioMismatch(t *testing.T) {
+	program := vm.Program{Builtins: []string{builtins.PEDERSEN_BUILTIN_NAME}, Identifiers: make(map[string]vm.Identifier)}
+	runner, err := runners.NewCairoRunner(program, "small", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	for i, builtin := range runner.Layout.Builtins {
+		if builtin.Name() == builtins.PEDERSEN_BUILTIN_NAME {
+			runner.Layout.Builtins[i] = builtins.NewPedersenBuiltinRunner(128)
+		}
+	}
+	_, err = runner.Initialize()
+	if err == nil {
+		t.Errorf("Initialize should have failed with a mismatched pedersen ratio")
+	}
+}
+
 func TestIncludedBuiltinsPlainLayoutNoProofMode(t *testing.T) {
 	cairoRunConfig := cairo_run.CairoRunConfig{DisableTracePadding: false, Layout: "small", ProofMode: false}
 	// Testing for a program with no builtins
@@ -277,6 +361,75 @@ func TestIncludedBuiltinsPlainLayoutNoProofMode(t *testing.T) {
 	}
 }
 
+func TestIncludedBuiltinsProofModeReportInclusion(t *testing.T) {
+	program := vm.Program{Builtins: []string{builtins.OUTPUT_BUILTIN_NAME}}
+	runner, err := runners.NewCairoRunner(program, "small", true)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	// In proof mode every builtin of the layout is added, but only the ones used by the program are included
+	if len(runner.Vm.BuiltinRunners) < 2 {
+		t.Errorf("Expected all of the layout's builtins, found %d", len(runner.Vm.BuiltinRunners))
+		return
+	}
+	for _, builtin := range runner.Vm.BuiltinRunners {
+		expected := builtin.Name() == builtins.OUTPUT_BUILTIN_NAME
+		if builtin.IsIncluded() != expected {
+			t.Errorf("Builtin %s should have IsIncluded %t", builtin.Name(), expected)
+		}
+	}
+}
+
+func TestAllowMissingBuiltinsPlainLayout(t *testing.T) {
+	cairoRunConfig := cairo_run.CairoRunConfig{Layout: "plain", AllowMissingBuiltins: true}
+	runner, err := cairo_run.CairoRun("../../cairo_programs/bitwise_builtin_test.json", cairoRunConfig)
+	if err != nil {
+		t.Errorf("Program execution failed with error: %s", err)
+		return
+	}
+	if len(runner.Vm.BuiltinRunners) != 1 || runner.Vm.BuiltinRunners[0].Name() != builtins.BITWISE_BUILTIN_NAME {
+		t.Errorf("Expected only the bitwise builtin, found %d builtins", len(runner.Vm.BuiltinRunners))
+	}
+}
+
+func TestMissingBuiltinsPlainLayoutFailsByDefault(t *testing.T) {
+	cairoRunConfig := cairo_run.CairoRunConfig{Layout: "plain"}
+	_, err := cairo_run.CairoRun("../../cairo_programs/bitwise_builtin_test.json", cairoRunConfig)
+	if err == nil {
+		t.Errorf("Program execution should fail with a builtin missing from the layout")
+	}
+}
+
+func TestAllowMissingBuiltinsKeepsProgramOrder(t *testing.T) {
+	program := vm.Program{Builtins: []string{builtins.OUTPUT_BUILTIN_NAME, builtins.PEDERSEN_BUILTIN_NAME, builtins.RANGE_CHECK_BUILTIN_NAME}}
+	runner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	runner.AllowMissingBuiltins = true
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	if len(runner.Vm.BuiltinRunners) != len(program.Builtins) {
+		t.Errorf("Expected %d builtins, found %d", len(program.Builtins), len(runner.Vm.BuiltinRunners))
+		return
+	}
+	for i, builtin := range runner.Vm.BuiltinRunners {
+		if builtin.Name() != program.Builtins[i] || !builtin.IsIncluded() {
+			t.Errorf("Expected builtin %s to be included at position %d, found %s", program.Builtins[i], i, builtin.Name())
+		}
+	}
+}
+
 // FIXME: This test should changed once the `small` layout is properly implemented. ATM we don't have all
 // its builtins implemented.
 func TestIncludedBuiltinsSmallLayoutNoProofMode(t *testing.T) {
@@ -324,6 +477,51 @@ func TestIncludedBuiltinsSmallLayoutNoProofMode(t *testing.T) {
 	}
 }
 
+// Hint processor that compiles every hint into its name and records the hint data it executes
+type namedHintProcessor struct {
+	name     string
+	executed *[]any
+}
+
+func (p *namedHintProcessor) CompileHint(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager) (any, error) {
+	return p.name, nil
+}
+
+func (p *namedHintProcessor) ExecuteHint(vm *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	*p.executed = append(*p.executed, *hintData)
+	return nil
+}
+
+func TestHintDataIsCompiledByEachProcessor(t *testing.T) {
+	program := dryRunTestProgram()
+	program.Hints = map[uint][]parser.HintParams{0: {{Code: "hint"}}, 2: {{Code: "hint"}}}
+	runner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	executed := make([]any, 0)
+	err = runner.StepOnce(&namedHintProcessor{name: "first", executed: &executed})
+	if err != nil {
+		t.Errorf("StepOnce failed with error: %s", err)
+		return
+	}
+	err = runner.StepOnce(&namedHintProcessor{name: "second", executed: &executed})
+	if err != nil {
+		t.Errorf("StepOnce failed with error: %s", err)
+		return
+	}
+	expected := []any{"first", "second"}
+	if !reflect.DeepEqual(executed, expected) {
+		t.Errorf("Each processor should execute its own hint data. Expected %v, got %v", expected, executed)
+	}
+}
+
 func TestBuildHintDataMapEmpty(t *testing.T) {
 	program := vm.Program{}
 	runner, _ := runners.NewCairoRunner(program, "plain", false)
@@ -510,6 +708,90 @@ func TestWriteOutputFromPresentMemoryNegOutput(t *testing.T) {
 	}
 }
 
+func TestGetOutputStringsSigned(t *testing.T) {
+	empty_identifiers := make(map[string]vm.Identifier, 0)
+	program_builtins := []string{builtins.OUTPUT_BUILTIN_NAME}
+	program := vm.Program{Identifiers: empty_identifiers, Builtins: program_builtins}
+	runner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+
+	runner.Vm.Segments.Memory.Insert(memory.NewRelocatable(2, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltOne()))
+	runner.Vm.Segments.Memory.Insert(memory.NewRelocatable(2, 1), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromDecString("-1")))
+	err = runner.EndRun(false, false, &runner.Vm, &hints.CairoVmHintProcessor{})
+	if err != nil {
+		t.Errorf("EndRun error in test: %s", err)
+		return
+	}
+
+	output, err := runner.GetOutputStrings(true)
+	if err != nil {
+		t.Errorf("GetOutputStrings error in test: %s", err)
+		return
+	}
+	expected := []string{"1", "-1"}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Wrong output. Expected %v, got %v", expected, output)
+	}
+
+	output, err = runner.GetOutputStrings(false)
+	if err != nil {
+		t.Errorf("GetOutputStrings error in test: %s", err)
+		return
+	}
+	expected = []string{"1", "3618502788666131213697322783095070105623107215331596699973092056135872020480"}
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Wrong output. Expected %v, got %v", expected, output)
+	}
+}
+
+func TestGetOutputStringsWithoutOutputBuiltin(t *testing.T) {
+	runner, err := runners.NewCairoRunner(dryRunTestProgram(), "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	err = runner.EndRun(false, false, &runner.Vm, &hints.CairoVmHintProcessor{})
+	if err != nil {
+		t.Errorf("EndRun error in test: %s", err)
+		return
+	}
+	_, err = runner.GetOutputStrings(true)
+	if err == nil {
+		t.Errorf("GetOutputStrings should have failed without the output builtin")
+	}
+}
+
+func TestGetOutputStringsBeforeRunEnded(t *testing.T) {
+	program := vm.Program{Identifiers: make(map[string]vm.Identifier, 0), Builtins: []string{builtins.OUTPUT_BUILTIN_NAME}}
+	runner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	_, err = runner.GetOutputStrings(false)
+	if err == nil {
+		t.Errorf("GetOutputStrings should have failed before the run ended")
+	}
+}
+
 // Todo: Uncomment when we can add main entrypoint to program
 /*func TestWriteOutputUnorderedBuiltins(t *testing.T) {
 	program_data := make([]memory.MaybeRelocatable, 14)
@@ -679,6 +961,87 @@ func TestCheckDilutedCheckUsageInsufficientAllocatedCells(t *testing.T) {
 	}
 }
 
+func TestCheckDilutedCheckUsageCustomPool(t *testing.T) {
+	program := vm.Program{Data: nil, Builtins: nil, Identifiers: nil, Hints: nil, ReferenceManager: parser.ReferenceManager{}}
+
+	runner, err := runners.NewCairoRunner(program, "all_cairo", false)
+	if err != nil {
+		t.Error("Could not initialize Cairo Runner")
+		return
+	}
+	virtualMachine := vm.NewVirtualMachine()
+
+	virtualMachine.CurrentStep = 100
+	virtualMachine.BuiltinRunners = make([]builtins.BuiltinRunner, 0)
+
+	// The default pool needs 2^16 unused units, which 100 steps can't provide
+	err = runner.CheckDilutedCheckUsage(virtualMachine)
+	if err == nil {
+		t.Errorf("Check Diluted Check Usage Should Have failed With Insufficient Allocated Cells Error")
+		return
+	}
+
+	// A pool with 8 bits only needs 2^8 unused units
+	runner.Layout = runner.Layout.WithDilutedPool(4, 8, 16)
+	err = runner.CheckDilutedCheckUsage(virtualMachine)
+	if err != nil {
+		t.Errorf("Check Diluted Check Usage failed with a custom pool: %s", err)
+	}
+}
+
+func TestGetDilutedUsageMatchesCheck(t *testing.T) {
+	program := vm.Program{Data: nil, Builtins: nil, Identifiers: nil, Hints: nil, ReferenceManager: parser.ReferenceManager{}}
+
+	runner, err := runners.NewCairoRunner(program, "all_cairo", false)
+	if err != nil {
+		t.Error("Could not initialize Cairo Runner")
+		return
+	}
+	virtualMachine := vm.NewVirtualMachine()
+
+	virtualMachine.CurrentStep = 8192
+	bitwise := builtins.NewBitwiseBuiltinRunner(16)
+	virtualMachine.BuiltinRunners = []builtins.BuiltinRunner{bitwise}
+
+	used, available, err := runner.GetDilutedUsage(virtualMachine)
+	if err != nil {
+		t.Errorf("GetDilutedUsage failed with error: %s", err)
+		return
+	}
+	pool := runner.Layout.DilutedPoolInstance
+	expectedUsed := bitwise.GetUsedDilutedCheckUnits(pool.Spacing, pool.NBits) * (8192 / 16)
+	expectedAvailable := pool.UnitsPerStep * 8192
+	if used != expectedUsed || available != expectedAvailable {
+		t.Errorf("Wrong diluted usage. Expected (%d, %d), got (%d, %d)", expectedUsed, expectedAvailable, used, available)
+	}
+
+	err = runner.CheckDilutedCheckUsage(virtualMachine)
+	hasHeadroom := available-used >= 1<<pool.NBits
+	if hasHeadroom && err != nil {
+		t.Errorf("Check Diluted Check Usage failed with headroom: %s", err)
+	}
+	if !hasHeadroom && err == nil {
+		t.Errorf("Check Diluted Check Usage should have failed without headroom")
+	}
+}
+
+func TestGetDilutedUsageWithoutPoolInstance(t *testing.T) {
+	program := vm.Program{Data: nil, Builtins: nil, Identifiers: nil, Hints: nil, ReferenceManager: parser.ReferenceManager{}}
+
+	runner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Error("Could not initialize Cairo Runner")
+		return
+	}
+	virtualMachine := vm.NewVirtualMachine()
+	virtualMachine.CurrentStep = 100
+
+	used, available, err := runner.GetDilutedUsage(virtualMachine)
+	if err != nil || used != 0 || available != 0 {
+		t.Errorf("Expected no diluted usage without a pool, got (%d, %d, %v)", used, available, err)
+	}
+}
+
 func TestCheckDilutedCheckUsage(t *testing.T) {
 	program := vm.Program{Data: nil, Builtins: nil, Identifiers: nil, Hints: nil, ReferenceManager: parser.ReferenceManager{}}
 
@@ -717,3 +1080,819 @@ func TestCheckUsedCellsDilutedCheckUsageError(t *testing.T) {
 		t.Errorf("Check Used Cells Should Have failed With Insufficient Allocated Cells Error")
 	}
 }
+
+func TestRunUntilPCContextCancelled(t *testing.T) {
+	// Program consisting of a single `jmp rel 0` instruction (infinite loop)
+	program_data := make([]memory.MaybeRelocatable, 2)
+	program_data[0] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x10780017fff7fff))
+	program_data[1] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero())
+	empty_identifiers := make(map[string]vm.Identifier, 0)
+	program := vm.Program{Data: program_data, Identifiers: empty_identifiers}
+	// Create CairoRunner
+	runner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+	}
+	// Initialize the runner
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	hintProcessor := &hints.CairoVmHintProcessor{}
+	err = runner.RunUntilPCContext(ctx, end, hintProcessor)
+	if err != context.Canceled {
+		t.Errorf("RunUntilPCContext should have failed with context.Canceled, got: %v", err)
+	}
+	if runner.Vm.CurrentStep == 0 {
+		t.Errorf("RunUntilPCContext should have executed some steps before being cancelled")
+	}
+}
+
+// Hint processor that counts the hints it compiles and executes, without running them
+type countingHintProcessor struct {
+	compiledHints uint
+	executedHints uint
+	// Distinct constants maps received by ExecuteHint
+	constantsMaps map[uintptr]bool
+}
+
+func (p *countingHintProcessor) CompileHint(hintParams *parser.HintParams, referenceManager *parser.ReferenceManager) (any, error) {
+	p.compiledHints++
+	return hintParams.Code, nil
+}
+
+func (p *countingHintProcessor) ExecuteHint(vm *vm.VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
+	p.executedHints++
+	if p.constantsMaps == nil {
+		p.constantsMaps = make(map[uintptr]bool)
+	}
+	p.constantsMaps[reflect.ValueOf(*constants).Pointer()] = true
+	return nil
+}
+
+// Program consisting of a single `jmp rel 0` instruction (infinite loop) with a hint on it
+func loopWithHintTestProgram() vm.Program {
+	program_data := make([]memory.MaybeRelocatable, 2)
+	program_data[0] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x10780017fff7fff))
+	program_data[1] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero())
+	programHints := map[uint][]parser.HintParams{0: {{Code: "pass"}}}
+	identifiers := map[string]vm.Identifier{
+		"__main__.SIZE": {Type: "constant", Value: lambdaworks.FeltFromUint64(3)},
+	}
+	return vm.Program{Data: program_data, Identifiers: identifiers, Hints: programHints}
+}
+
+func TestRunForStepsCompilesHintsOnce(t *testing.T) {
+	runner, err := runners.NewCairoRunner(loopWithHintTestProgram(), "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	hintProcessor := &countingHintProcessor{}
+	for i := 0; i < 5; i++ {
+		err = runner.RunForSteps(2, &runner.Vm, hintProcessor)
+		if err != nil {
+			t.Errorf("RunForSteps failed with error: %s", err)
+			return
+		}
+	}
+	if hintProcessor.compiledHints != 1 {
+		t.Errorf("Hints should have been compiled once, got %d compilations", hintProcessor.compiledHints)
+	}
+	if hintProcessor.executedHints != 10 {
+		t.Errorf("Wrong amount of executed hints. Expected 10, got %d", hintProcessor.executedHints)
+	}
+}
+
+func TestRunForStepsExtractsConstantsOnce(t *testing.T) {
+	runner, err := runners.NewCairoRunner(loopWithHintTestProgram(), "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	hintProcessor := &countingHintProcessor{}
+	for i := 0; i < 5; i++ {
+		err = runner.RunForSteps(1, &runner.Vm, hintProcessor)
+		if err != nil {
+			t.Errorf("RunForSteps failed with error: %s", err)
+			return
+		}
+	}
+	// A new map would be received by the hints each time the constants are extracted
+	if len(hintProcessor.constantsMaps) != 1 {
+		t.Errorf("Constants should have been extracted once, got %d extractions", len(hintProcessor.constantsMaps))
+	}
+}
+
+// Mimics the proof mode padding loop, which runs one step at a time
+func BenchmarkRunForStepsPaddingLoop(b *testing.B) {
+	runner, err := runners.NewCairoRunner(loopWithHintTestProgram(), "plain", false)
+	if err != nil {
+		b.Fatalf("NewCairoRunner error in benchmark: %s", err)
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		b.Fatalf("Initialize error in benchmark: %s", err)
+	}
+	hintProcessor := &countingHintProcessor{}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err = runner.RunForSteps(1, &runner.Vm, hintProcessor)
+		if err != nil {
+			b.Fatalf("RunForSteps failed with error: %s", err)
+		}
+	}
+}
+
+// Proof mode program whose __start__ and __end__ are a `jmp rel 0` instruction, followed by
+// fillerSize cells that are never executed
+func proofModePaddingTestProgram(fillerSize int) vm.Program {
+	data := []string{"0x10780017fff7fff", "0x0"}
+	for i := 0; i < fillerSize; i++ {
+		data = append(data, "0x1")
+	}
+	program, _ := vm.DeserializeProgramJson(parser.CompiledJson{
+		Prime: lambdaworks.CAIRO_PRIME_HEX,
+		Data:  data,
+		Identifiers: map[string]parser.Identifier{
+			"__start__":          {PC: 0, Type: "label"},
+			"__end__":            {PC: 0, Type: "label"},
+			"__main__.__start__": {PC: 0, Type: "label"},
+			"__main__.__end__":   {PC: 0, Type: "label"},
+		},
+	})
+	return program
+}
+
+func TestEndRunProofModePadsTrace(t *testing.T) {
+	runner, err := runners.NewCairoRunner(proofModePaddingTestProgram(10), "small", true)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	hintProcessor := &hints.CairoVmHintProcessor{}
+	err = runner.RunUntilPC(end, hintProcessor)
+	if err != nil {
+		t.Errorf("RunUntilPC failed with error: %s", err)
+		return
+	}
+	err = runner.EndRun(false, false, &runner.Vm, hintProcessor)
+	if err != nil {
+		t.Errorf("EndRun failed with error: %s", err)
+		return
+	}
+	step := runner.Vm.CurrentStep
+	if step == 0 || step&(step-1) != 0 {
+		t.Errorf("The trace should be padded to a power of two, got %d steps", step)
+	}
+	if err = runner.CheckUsedCells(&runner.Vm); err != nil {
+		t.Errorf("CheckUsedCells should pass after padding, got %s", err)
+	}
+	// The program segment is frozen, so its size isn't affected by the padding
+	size, err := runner.Vm.Segments.GetSegmentUsedSize(uint(runner.ProgramBase.SegmentIndex))
+	if err != nil || size != 12 {
+		t.Errorf("Wrong program segment size. Expected 12, got %d, %v", size, err)
+	}
+}
+
+// Runs the proof mode padding loop of EndRun on a large program segment
+func BenchmarkEndRunProofMode(b *testing.B) {
+	program := proofModePaddingTestProgram(1000000)
+	hintProcessor := &hints.CairoVmHintProcessor{}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		runner, err := runners.NewCairoRunner(program, "small", true)
+		if err != nil {
+			b.Fatalf("NewCairoRunner error in benchmark: %s", err)
+		}
+		end, err := runner.Initialize()
+		if err != nil {
+			b.Fatalf("Initialize error in benchmark: %s", err)
+		}
+		if err = runner.RunUntilPC(end, hintProcessor); err != nil {
+			b.Fatalf("RunUntilPC failed with error: %s", err)
+		}
+		// Don't time the collection of the garbage left by the setup
+		runtime.GC()
+		b.StartTimer()
+		if err = runner.EndRun(false, false, &runner.Vm, hintProcessor); err != nil {
+			b.Fatalf("EndRun failed with error: %s", err)
+		}
+	}
+}
+
+func dryRunTestProgram() vm.Program {
+	// Program consisting of `[ap] = 1, ap++` followed by `ret`
+	program_data := make([]memory.MaybeRelocatable, 3)
+	program_data[0] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480680017fff8000))
+	program_data[1] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltOne())
+	program_data[2] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x208b7fff7fff7ffe))
+	empty_identifiers := make(map[string]vm.Identifier, 0)
+	return vm.Program{Data: program_data, Identifiers: empty_identifiers}
+}
+
+func TestInitializeWithStackMatchesMainEntrypoint(t *testing.T) {
+	program := dryRunTestProgram()
+	program.Builtins = []string{builtins.OUTPUT_BUILTIN_NAME}
+	mainRunner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	mainEnd, err := mainRunner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+
+	stackRunner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	// The output builtin's segment comes after the program and execution segments
+	stack := []memory.MaybeRelocatable{*memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(2, 0))}
+	stackEnd, err := stackRunner.InitializeWithStack(0, stack)
+	if err != nil {
+		t.Errorf("InitializeWithStack error in test: %s", err)
+		return
+	}
+
+	if mainEnd != stackEnd {
+		t.Errorf("Different end pointers. Main: %+v, with stack: %+v", mainEnd, stackEnd)
+	}
+	if mainRunner.Vm.RunContext != stackRunner.Vm.RunContext {
+		t.Errorf("Different run contexts. Main: %s, with stack: %s", mainRunner.Vm.RunContext, stackRunner.Vm.RunContext)
+	}
+	if equal, diff := mainRunner.Vm.Segments.Memory.Equals(&stackRunner.Vm.Segments.Memory); !equal {
+		t.Errorf("Memories differ at %+v", diff)
+	}
+	if len(stack) != 1 {
+		t.Errorf("InitializeWithStack should not modify the given stack")
+	}
+}
+
+func TestInitializeWithStackEntrypointOutOfBounds(t *testing.T) {
+	runner, err := runners.NewCairoRunner(dryRunTestProgram(), "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.InitializeWithStack(3, nil)
+	if err == nil {
+		t.Errorf("InitializeWithStack should fail with an entrypoint out of the program")
+	}
+}
+
+func TestGetRunContextAfterSteps(t *testing.T) {
+	runner, err := runners.NewCairoRunner(dryRunTestProgram(), "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	ap, fp, pc := runner.Vm.GetRunContext()
+	if pc != memory.NewRelocatable(0, 0) || ap != memory.NewRelocatable(1, 2) || fp != memory.NewRelocatable(1, 2) {
+		t.Errorf("Wrong initial run context: %s", runner.Vm.RunContext)
+	}
+	err = runner.RunForSteps(1, &runner.Vm, &hints.CairoVmHintProcessor{})
+	if err != nil {
+		t.Errorf("RunForSteps failed with error: %s", err)
+		return
+	}
+	ap, fp, pc = runner.Vm.GetRunContext()
+	if pc != memory.NewRelocatable(0, 2) || ap != memory.NewRelocatable(1, 3) || fp != memory.NewRelocatable(1, 2) {
+		t.Errorf("Wrong run context after one step: %s", runner.Vm.RunContext)
+	}
+	// The returned registers are copies
+	ap.Offset = 100
+	if runner.Vm.RunContext.Ap.Offset != 3 {
+		t.Errorf("Modifying the returned ap should not modify the vm's registers")
+	}
+}
+
+func TestStepOnce(t *testing.T) {
+	runner, err := runners.NewCairoRunner(dryRunTestProgram(), "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	err = runner.StepOnce(&hints.CairoVmHintProcessor{})
+	if err != nil {
+		t.Errorf("StepOnce failed with error: %s", err)
+		return
+	}
+	if runner.Vm.CurrentStep != 1 {
+		t.Errorf("StepOnce should advance exactly one step, CurrentStep is %d", runner.Vm.CurrentStep)
+	}
+	value, err := runner.Vm.Segments.Memory.GetFelt(memory.NewRelocatable(1, 2))
+	if err != nil || value != lambdaworks.FeltOne() {
+		t.Errorf("The first instruction should have written 1 at (1, 2), got %v", value)
+	}
+}
+
+func TestRunUntilBreakpoint(t *testing.T) {
+	runner, err := runners.NewCairoRunner(dryRunTestProgram(), "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	hintProcessor := &hints.CairoVmHintProcessor{}
+	breakpoints := map[uint]struct{}{2: {}}
+	hitPc, err := runner.RunUntilBreakpoint(breakpoints, hintProcessor)
+	if err != nil {
+		t.Errorf("RunUntilBreakpoint failed with error: %s", err)
+		return
+	}
+	if hitPc == nil || *hitPc != 2 || runner.Vm.RunContext.Pc != memory.NewRelocatable(0, 2) {
+		t.Errorf("RunUntilBreakpoint should have stopped at offset 2, pc is %v", runner.Vm.RunContext.Pc)
+		return
+	}
+	// Resuming runs the program until its end
+	hitPc, err = runner.RunUntilBreakpoint(breakpoints, hintProcessor)
+	if err != nil {
+		t.Errorf("RunUntilBreakpoint failed with error: %s", err)
+		return
+	}
+	if hitPc != nil || runner.Vm.RunContext.Pc != end {
+		t.Errorf("RunUntilBreakpoint should have run until the end, pc is %v", runner.Vm.RunContext.Pc)
+	}
+}
+
+func TestProfileLoopBody(t *testing.T) {
+	// Program counting down from 3 to 0:
+	// [ap] = 3, ap++; loop: [ap] = [ap - 1] + (-1), ap++; jmp rel -2 if [ap - 1] != 0; ret
+	program_data := []memory.MaybeRelocatable{
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480680017fff8000)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(3)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x482480017fff8000)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromDecString("-1")),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x20680017fff7fff)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromDecString("-2")),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x208b7fff7fff7ffe)),
+	}
+	program := vm.Program{Data: program_data, Identifiers: make(map[string]vm.Identifier, 0)}
+	runner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	if runner.Vm.GetProfile() != nil {
+		t.Errorf("GetProfile should return nil before profiling is enabled")
+	}
+	runner.Vm.EnableProfiling()
+	err = runner.RunUntilPC(end, &hints.CairoVmHintProcessor{})
+	if err != nil {
+		t.Errorf("RunUntilPC failed with error: %s", err)
+		return
+	}
+	expectedProfile := map[uint]uint{0: 1, 2: 3, 4: 3, 6: 1}
+	profile := runner.Vm.GetProfile()
+	if !reflect.DeepEqual(profile, expectedProfile) {
+		t.Errorf("Wrong profile. Expected %v, got %v", expectedProfile, profile)
+	}
+}
+
+func TestDryRunStepCount(t *testing.T) {
+	runner, err := runners.NewCairoRunner(dryRunTestProgram(), "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+	}
+	steps, err := runner.DryRunStepCount(&hints.CairoVmHintProcessor{}, 100)
+	if err != nil {
+		t.Errorf("DryRunStepCount failed with error: %s", err)
+	}
+	if steps != 2 {
+		t.Errorf("DryRunStepCount returned wrong amount of steps. Expected 2, got %d", steps)
+	}
+	// The runner should be left untouched
+	if runner.Vm.Segments.Memory.NumSegments() != 0 || runner.Vm.CurrentStep != 0 || len(runner.Vm.Trace) != 0 {
+		t.Errorf("DryRunStepCount should not modify the runner")
+	}
+}
+
+func outputBuiltinTestProgram() vm.Program {
+	// Program consisting of `[ap] = [fp - 3], ap++` followed by `ret`, returning the output pointer it received
+	program_data := make([]memory.MaybeRelocatable, 2)
+	program_data[0] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480a7ffd7fff8000))
+	program_data[1] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x208b7fff7fff7ffe))
+	return vm.Program{Data: program_data, Builtins: []string{builtins.OUTPUT_BUILTIN_NAME}, Identifiers: make(map[string]vm.Identifier)}
+}
+
+// Checks that the layout's output builtin was never initialized or run
+func checkLayoutOutputBuiltinUntouched(t *testing.T, runner *runners.CairoRunner) {
+	for _, builtin := range runner.Layout.Builtins {
+		output, ok := builtin.(*builtins.OutputBuiltinRunner)
+		if !ok {
+			continue
+		}
+		if output.Base() != (memory.Relocatable{}) || output.StopPtr != nil {
+			t.Errorf("The layout's output builtin should be untouched, got base %v and stop ptr %v", output.Base(), output.StopPtr)
+		}
+	}
+}
+
+func TestDryRunStepCountThenRealRun(t *testing.T) {
+	runner, err := runners.NewCairoRunner(outputBuiltinTestProgram(), "small", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	hintProcessor := &hints.CairoVmHintProcessor{}
+	steps, err := runner.DryRunStepCount(hintProcessor, 100)
+	if err != nil || steps != 2 {
+		t.Errorf("DryRunStepCount returned %d, %v. Expected 2 steps", steps, err)
+		return
+	}
+	checkLayoutOutputBuiltinUntouched(t, runner)
+
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	if err = runner.RunUntilPC(end, hintProcessor); err != nil {
+		t.Errorf("RunUntilPC failed with error: %s", err)
+		return
+	}
+	if err = runner.EndRun(false, false, &runner.Vm, hintProcessor); err != nil {
+		t.Errorf("EndRun failed with error: %s", err)
+		return
+	}
+	if err = runner.ReadReturnValues(&runner.Vm); err != nil {
+		t.Errorf("ReadReturnValues failed with error: %s", err)
+		return
+	}
+	output, ok := runner.Vm.BuiltinRunners[0].(*builtins.OutputBuiltinRunner)
+	if !ok || len(runner.Vm.BuiltinRunners) != 1 {
+		t.Errorf("Expected the output builtin to be the only builtin, got %v", runner.Vm.BuiltinRunners)
+		return
+	}
+	if output.Base() != memory.NewRelocatable(2, 0) || output.StopPtr == nil || *output.StopPtr != 0 {
+		t.Errorf("Wrong output builtin state. Base: %v, stop ptr: %v", output.Base(), output.StopPtr)
+	}
+	// The real run doesn't affect the layout either
+	checkLayoutOutputBuiltinUntouched(t, runner)
+}
+
+func TestDryRunStepCountMaxStepsExceeded(t *testing.T) {
+	runner, err := runners.NewCairoRunner(dryRunTestProgram(), "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+	}
+	steps, err := runner.DryRunStepCount(&hints.CairoVmHintProcessor{}, 1)
+	if err != runners.ErrDryRunMaxStepsExceeded {
+		t.Errorf("DryRunStepCount should have failed with ErrDryRunMaxStepsExceeded, got: %v", err)
+	}
+	if steps != 1 {
+		t.Errorf("DryRunStepCount returned wrong amount of steps. Expected 1, got %d", steps)
+	}
+}
+
+func TestRunUntilPCErrorIncludesSourceLocation(t *testing.T) {
+	// Program consisting of `[ap] = 2, ap++` followed by a failing `assert [ap - 1] = 1`
+	program_data := make([]memory.MaybeRelocatable, 4)
+	program_data[0] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480680017fff8000))
+	program_data[1] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2))
+	program_data[2] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x400680017fff7fff))
+	program_data[3] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltOne())
+	program := vm.Program{
+		Data:        program_data,
+		Identifiers: make(map[string]vm.Identifier, 0),
+		InstructionLocations: map[uint]parser.InstructionLocation{
+			2: {Inst: parser.Location{StartLine: 42, InputFile: map[string]string{"filename": "foo.cairo"}}},
+		},
+	}
+	runner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+	}
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+	}
+	err = runner.RunUntilPC(end, &hints.CairoVmHintProcessor{})
+	if err == nil || !strings.HasPrefix(err.Error(), "error at foo.cairo:42: ") {
+		t.Errorf("RunUntilPC should have failed with an error including the source location, got: %v", err)
+	}
+}
+
+func setupVmForCheckStopPointers(stopPtr uint) *vm.VirtualMachine {
+	virtualMachine := vm.NewVirtualMachine()
+	pedersen := builtins.NewPedersenBuiltinRunner(256)
+	pedersen.Include(true)
+	pedersen.InitializeSegments(&virtualMachine.Segments)
+	pedersen.StopPtr = &stopPtr
+	virtualMachine.BuiltinRunners = append(virtualMachine.BuiltinRunners, pedersen)
+	virtualMachine.Segments.SegmentUsedSizes[0] = 6
+	return virtualMachine
+}
+
+func TestCheckStopPointersOk(t *testing.T) {
+	runner, err := runners.NewCairoRunner(vm.Program{}, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+	}
+	err = runner.CheckStopPointers(setupVmForCheckStopPointers(6))
+	if err != nil {
+		t.Errorf("CheckStopPointers failed with error: %s", err)
+	}
+}
+
+func TestCheckStopPointersCorruptedStopPointer(t *testing.T) {
+	runner, err := runners.NewCairoRunner(vm.Program{}, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+	}
+	err = runner.CheckStopPointers(setupVmForCheckStopPointers(5))
+	if !errors.Is(err, builtins.ErrInvalidStopPointer) {
+		t.Errorf("CheckStopPointers should have failed with ErrInvalidStopPointer, got: %v", err)
+	}
+}
+
+func TestCheckStopPointersMissingStopPointer(t *testing.T) {
+	runner, err := runners.NewCairoRunner(vm.Program{}, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+	}
+	virtualMachine := vm.NewVirtualMachine()
+	virtualMachine.BuiltinRunners = append(virtualMachine.BuiltinRunners, builtins.NewOutputBuiltinRunner())
+	err = runner.CheckStopPointers(virtualMachine)
+	if !errors.Is(err, builtins.ErrNoStopPointer) {
+		t.Errorf("CheckStopPointers should have failed with ErrNoStopPointer, got: %v", err)
+	}
+}
+
+func runFibonacciForReset(t *testing.T, runner *runners.CairoRunner) {
+	hintProcessor := hints.CairoVmHintProcessor{}
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	err = runner.RunUntilPC(end, &hintProcessor)
+	if err != nil {
+		t.Errorf("RunUntilPC error in test: %s", err)
+		return
+	}
+	err = runner.EndRun(false, false, &runner.Vm, &hintProcessor)
+	if err != nil {
+		t.Errorf("EndRun error in test: %s", err)
+	}
+}
+
+func TestGetFinalStateAfterRun(t *testing.T) {
+	runner, err := runners.NewCairoRunner(dryRunTestProgram(), "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	hintProcessor := hints.CairoVmHintProcessor{}
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	_, _, _, err = runner.GetFinalState()
+	if err == nil {
+		t.Errorf("GetFinalState should have failed before the run ended")
+	}
+	err = runner.RunUntilPC(end, &hintProcessor)
+	if err != nil {
+		t.Errorf("RunUntilPC error in test: %s", err)
+		return
+	}
+	err = runner.EndRun(false, false, &runner.Vm, &hintProcessor)
+	if err != nil {
+		t.Errorf("EndRun error in test: %s", err)
+		return
+	}
+	pc, ap, fp, err := runner.GetFinalState()
+	if err != nil {
+		t.Errorf("GetFinalState error in test: %s", err)
+		return
+	}
+	if pc != end {
+		t.Errorf("Wrong final pc. Expected %+v, got %+v", end, pc)
+	}
+	if ap != runner.Vm.RunContext.Ap || fp != runner.Vm.RunContext.Fp {
+		t.Errorf("Wrong final registers. Expected ap %+v and fp %+v, got %+v and %+v", runner.Vm.RunContext.Ap, runner.Vm.RunContext.Fp, ap, fp)
+	}
+}
+
+func TestResetRunnerTwoRunsProduceSameMemory(t *testing.T) {
+	compiledProgram, err := parser.Parse("../../cairo_programs/fibonacci.json")
+	if err != nil {
+		t.Errorf("Parse error in test: %s", err)
+		return
+	}
+	program, err := vm.DeserializeProgramJson(compiledProgram)
+	if err != nil {
+		t.Errorf("DeserializeProgramJson failed with error: %s", err)
+		return
+	}
+	runner, err := runners.NewCairoRunner(program, "all_cairo", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	runFibonacciForReset(t, runner)
+	firstMemory := runner.Vm.Segments.Memory.Clone()
+	firstSteps := runner.Vm.CurrentStep
+
+	err = runner.Reset()
+	if err != nil {
+		t.Errorf("Reset failed with error: %s", err)
+		return
+	}
+	if runner.RunEnded || runner.SegmentsFinalized || runner.Vm.CurrentStep != 0 {
+		t.Errorf("Reset should clear the run state")
+	}
+	runFibonacciForReset(t, runner)
+
+	if runner.Vm.CurrentStep != firstSteps {
+		t.Errorf("Wrong step count after reset. Expected %d, got %d", firstSteps, runner.Vm.CurrentStep)
+	}
+	equal, addr := firstMemory.Equals(&runner.Vm.Segments.Memory)
+	if !equal {
+		t.Errorf("Memories of both runs differ at %v", addr)
+	}
+}
+
+func TestResetRunnerClearsBuiltinsAndKeepsVmConfig(t *testing.T) {
+	runner, err := runners.NewCairoRunner(outputBuiltinTestProgram(), "small", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	runner.Vm.TraceDisabled = true
+	runner.Vm.EnableProfiling()
+	runner.Vm.Segments.MaxSegments = 10
+	runner.Vm.Segments.Memory.SetMaxCells(100)
+	hintProcessor := &hints.CairoVmHintProcessor{}
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	if err = runner.RunUntilPC(end, hintProcessor); err != nil {
+		t.Errorf("RunUntilPC failed with error: %s", err)
+		return
+	}
+	if err = runner.EndRun(false, false, &runner.Vm, hintProcessor); err != nil {
+		t.Errorf("EndRun failed with error: %s", err)
+		return
+	}
+	if err = runner.ReadReturnValues(&runner.Vm); err != nil {
+		t.Errorf("ReadReturnValues failed with error: %s", err)
+		return
+	}
+
+	if err = runner.Reset(); err != nil {
+		t.Errorf("Reset failed with error: %s", err)
+		return
+	}
+	if len(runner.Vm.BuiltinRunners) != 0 {
+		t.Errorf("Reset should clear the builtins, got %v", runner.Vm.BuiltinRunners)
+	}
+	checkLayoutOutputBuiltinUntouched(t, runner)
+	if !runner.Vm.TraceDisabled || runner.Vm.Segments.MaxSegments != 10 || runner.Vm.Segments.Memory.MaxCells() != 100 {
+		t.Errorf("Reset should keep the VM configuration")
+	}
+	if profile := runner.Vm.GetProfile(); profile == nil || len(profile) != 0 {
+		t.Errorf("Reset should keep profiling enabled with no executions counted, got %v", profile)
+	}
+}
+
+func TestResetRunnerKeepsCustomLayout(t *testing.T) {
+	runner, err := runners.NewCairoRunner(dryRunTestProgram(), "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	runner.Layout = runner.Layout.WithDilutedPool(4, 8, 16)
+	customLayout := runner.Layout
+	err = runner.Reset()
+	if err != nil {
+		t.Errorf("Reset failed with error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(runner.Layout, customLayout) {
+		t.Errorf("Reset should keep the custom layout. Expected %+v, got %+v", customLayout, runner.Layout)
+	}
+}
+
+func TestResetRunnerMidRun(t *testing.T) {
+	compiledProgram, err := parser.Parse("../../cairo_programs/fibonacci.json")
+	if err != nil {
+		t.Errorf("Parse error in test: %s", err)
+		return
+	}
+	program, err := vm.DeserializeProgramJson(compiledProgram)
+	if err != nil {
+		t.Errorf("DeserializeProgramJson failed with error: %s", err)
+		return
+	}
+	runner, err := runners.NewCairoRunner(program, "all_cairo", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize error in test: %s", err)
+		return
+	}
+	err = runner.RunForSteps(1, &runner.Vm, &hints.CairoVmHintProcessor{})
+	if err != nil {
+		t.Errorf("RunForSteps error in test: %s", err)
+	}
+	err = runner.Reset()
+	if err != runners.ErrResetMidRun {
+		t.Errorf("Expected ErrResetMidRun, got %v", err)
+	}
+}
+
+func TestGetBuiltinSegmentInfoPedersenAndRangeCheck(t *testing.T) {
+	cairoRunConfig := cairo_run.CairoRunConfig{DisableTracePadding: false, Layout: "small", ProofMode: true}
+	runner, err := cairo_run.CairoRun("../../cairo_programs/proof_programs/pedersen_test.json", cairoRunConfig)
+	if err != nil {
+		t.Errorf("Program execution failed with error: %s", err)
+		return
+	}
+	segmentsInfo, err := runner.GetBuiltinSegmentInfo()
+	if err != nil {
+		t.Errorf("GetBuiltinSegmentInfo failed with error: %s", err)
+		return
+	}
+	expectedNames := []string{builtins.OUTPUT_BUILTIN_NAME, builtins.PEDERSEN_BUILTIN_NAME, builtins.RANGE_CHECK_BUILTIN_NAME}
+	expectedRatios := []uint{0, 256, 8}
+	if len(segmentsInfo) != len(expectedNames) {
+		t.Errorf("Wrong amount of segments info. Expected %d, got %d", len(expectedNames), len(segmentsInfo))
+		return
+	}
+	for i, info := range segmentsInfo {
+		if info.Name != expectedNames[i] || info.Ratio != expectedRatios[i] {
+			t.Errorf("Wrong segment info at position %d: %+v", i, info)
+		}
+		builtin := runner.Vm.BuiltinRunners[i]
+		if info.Index != uint(builtin.Base().SegmentIndex) {
+			t.Errorf("Wrong segment index for %s. Expected %d, got %d", info.Name, builtin.Base().SegmentIndex, info.Index)
+		}
+	}
+	if segmentsInfo[1].Size == 0 {
+		t.Errorf("Expected the pedersen segment to be used")
+	}
+}
+
+func TestGetBuiltinSegmentInfoBeforeRunEnded(t *testing.T) {
+	runner, err := runners.NewCairoRunner(vm.Program{}, "small", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+		return
+	}
+	_, err = runner.GetBuiltinSegmentInfo()
+	if err == nil {
+		t.Errorf("GetBuiltinSegmentInfo should fail before the run ends")
+	}
+}
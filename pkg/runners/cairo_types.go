@@ -0,0 +1,249 @@
+package runners
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+const FELT_CAIRO_TYPE = "felt"
+
+// A member of a Cairo struct, as described by the program's struct identifiers
+type structMember struct {
+	Name      string
+	CairoType string
+	Offset    uint
+}
+
+// Builds the arguments of an entrypoint from their Cairo types, laying out structs in place and
+// writing the values behind pointer types (arrays) into new memory segments.
+// Supported types are `felt`, pointers (`T*`) and the full names of the program's structs.
+// Felt values are given as lambdaworks.Felt, pointers and structs as slices or arrays with one element
+// per array item/struct member (a memory.Relocatable can also be given for a pointer)
+func (r *CairoRunner) BuildArgsFromTypes(types []string, values []any) ([]memory.MaybeRelocatable, error) {
+	if len(types) != len(values) {
+		return nil, errors.Errorf("Got %d types but %d values", len(types), len(values))
+	}
+	args := make([]memory.MaybeRelocatable, 0, len(types))
+	for i, cairoType := range types {
+		arg, err := r.buildArg(cairoType, values[i])
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg...)
+	}
+	return args, nil
+}
+
+func (r *CairoRunner) buildArg(cairoType string, value any) ([]memory.MaybeRelocatable, error) {
+	if cairoType == FELT_CAIRO_TYPE {
+		felt, ok := value.(lambdaworks.Felt)
+		if !ok {
+			return nil, errors.Errorf("Expected a Felt value for type felt, got %T", value)
+		}
+		return []memory.MaybeRelocatable{*memory.NewMaybeRelocatableFelt(felt)}, nil
+	}
+
+	if strings.HasSuffix(cairoType, "*") {
+		if ptr, ok := value.(memory.Relocatable); ok {
+			return []memory.MaybeRelocatable{*memory.NewMaybeRelocatableRelocatable(ptr)}, nil
+		}
+		elements, ok := toSlice(value)
+		if !ok {
+			return nil, errors.Errorf("Expected a slice or array value for type %s, got %T", cairoType, value)
+		}
+		pointeeType := strings.TrimSuffix(cairoType, "*")
+		data := make([]memory.MaybeRelocatable, 0, len(elements))
+		for _, element := range elements {
+			elementData, err := r.buildArg(pointeeType, element)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, elementData...)
+		}
+		base, err := r.Vm.Segments.AddSegment()
+		if err != nil {
+			return nil, err
+		}
+		_, err = r.Vm.Segments.LoadData(base, &data)
+		if err != nil {
+			return nil, err
+		}
+		return []memory.MaybeRelocatable{*memory.NewMaybeRelocatableRelocatable(base)}, nil
+	}
+
+	members, err := r.getStructMembers(cairoType)
+	if err != nil {
+		return nil, err
+	}
+	elements, ok := toSlice(value)
+	if !ok || len(elements) != len(members) {
+		return nil, errors.Errorf("Expected a slice or array with %d values for struct %s, got %v", len(members), cairoType, value)
+	}
+	data := make([]memory.MaybeRelocatable, 0, len(members))
+	for i, member := range members {
+		memberData, err := r.buildArg(member.CairoType, elements[i])
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, memberData...)
+	}
+	return data, nil
+}
+
+// Returns the members of the struct with the given full name, sorted by offset
+func (r *CairoRunner) getStructMembers(structName string) ([]structMember, error) {
+	identifier, ok := r.Program.Identifiers[structName]
+	if !ok || identifier.Type != "struct" {
+		return nil, errors.Errorf("Unknown Cairo type %s", structName)
+	}
+	members := make([]structMember, 0, len(identifier.Members))
+	for name, rawMember := range identifier.Members {
+		member, ok := rawMember.(map[string]any)
+		if !ok {
+			return nil, errors.Errorf("Malformed member %s of struct %s", name, structName)
+		}
+		cairoType, ok := member["cairo_type"].(string)
+		if !ok {
+			return nil, errors.Errorf("Malformed member %s of struct %s", name, structName)
+		}
+		var offset uint
+		switch rawOffset := member["offset"].(type) {
+		// Numbers are deserialized as float64 from the compiled json
+		case float64:
+			offset = uint(rawOffset)
+		case int:
+			offset = uint(rawOffset)
+		default:
+			return nil, errors.Errorf("Malformed member %s of struct %s", name, structName)
+		}
+		members = append(members, structMember{Name: name, CairoType: cairoType, Offset: offset})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Offset < members[j].Offset })
+	return members, nil
+}
+
+// Converts a slice or array of any type into a slice of its elements
+func toSlice(value any) ([]any, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+	elements := make([]any, v.Len())
+	for i := range elements {
+		elements[i] = v.Index(i).Interface()
+	}
+	return elements, true
+}
+
+// Reads the values returned by the last run (located right below ap) and interprets them according to their Cairo types.
+// Felts are returned as lambdaworks.Felt and structs as slices with one element per member (the same format
+// BuildArgsFromTypes receives). Pointers are dereferenced: following Cairo's `(len, ptr)` convention, a pointer that
+// comes right after a felt is read as an array of that many elements, otherwise it is read as a pointer to a single value.
+// Pointers nested inside structs or arrays are not dereferenced and are returned as memory.Relocatable
+func (r *CairoRunner) ReadTypedReturnValues(types []string) ([]any, error) {
+	totalSize := uint(0)
+	for _, cairoType := range types {
+		size, err := r.getCairoTypeSize(cairoType)
+		if err != nil {
+			return nil, err
+		}
+		totalSize += size
+	}
+	addr, err := r.Vm.RunContext.Ap.SubUint(totalSize)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, 0, len(types))
+	for i, cairoType := range types {
+		var value any
+		if strings.HasSuffix(cairoType, "*") {
+			ptr, err := r.Vm.Segments.Memory.GetRelocatable(addr)
+			if err != nil {
+				return nil, err
+			}
+			pointeeType := strings.TrimSuffix(cairoType, "*")
+			if i > 0 && types[i-1] == FELT_CAIRO_TYPE {
+				length, err := values[i-1].(lambdaworks.Felt).ToU64()
+				if err != nil {
+					return nil, err
+				}
+				value, err = r.readArray(pointeeType, ptr, uint(length))
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				value, err = r.readTypedValue(pointeeType, ptr)
+				if err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			value, err = r.readTypedValue(cairoType, addr)
+			if err != nil {
+				return nil, err
+			}
+		}
+		values = append(values, value)
+		size, _ := r.getCairoTypeSize(cairoType)
+		addr = addr.AddUint(size)
+	}
+	return values, nil
+}
+
+// Reads length consecutive values of the given Cairo type starting at addr
+func (r *CairoRunner) readArray(cairoType string, addr memory.Relocatable, length uint) ([]any, error) {
+	size, err := r.getCairoTypeSize(cairoType)
+	if err != nil {
+		return nil, err
+	}
+	elements := make([]any, 0, length)
+	for i := uint(0); i < length; i++ {
+		element, err := r.readTypedValue(cairoType, addr.AddUint(i*size))
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
+
+// Reads the value of the given Cairo type located at addr, without dereferencing pointers
+func (r *CairoRunner) readTypedValue(cairoType string, addr memory.Relocatable) (any, error) {
+	if cairoType == FELT_CAIRO_TYPE {
+		return r.Vm.Segments.Memory.GetFelt(addr)
+	}
+	if strings.HasSuffix(cairoType, "*") {
+		return r.Vm.Segments.Memory.GetRelocatable(addr)
+	}
+	members, err := r.getStructMembers(cairoType)
+	if err != nil {
+		return nil, err
+	}
+	memberValues := make([]any, 0, len(members))
+	for _, member := range members {
+		memberValue, err := r.readTypedValue(member.CairoType, addr.AddUint(member.Offset))
+		if err != nil {
+			return nil, err
+		}
+		memberValues = append(memberValues, memberValue)
+	}
+	return memberValues, nil
+}
+
+// Returns the amount of memory cells taken by a value of the given Cairo type
+func (r *CairoRunner) getCairoTypeSize(cairoType string) (uint, error) {
+	if cairoType == FELT_CAIRO_TYPE || strings.HasSuffix(cairoType, "*") {
+		return 1, nil
+	}
+	identifier, ok := r.Program.Identifiers[cairoType]
+	if !ok || identifier.Type != "struct" {
+		return 0, errors.Errorf("Unknown Cairo type %s", cairoType)
+	}
+	return uint(identifier.Size), nil
+}
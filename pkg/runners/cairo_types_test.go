@@ -0,0 +1,140 @@
+package runners_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+const UINT256_CAIRO_TYPE = "starkware.cairo.common.uint256.Uint256"
+
+func newRunnerWithUint256Struct(t *testing.T) *runners.CairoRunner {
+	program := vm.Program{
+		Identifiers: map[string]vm.Identifier{
+			UINT256_CAIRO_TYPE: {
+				FullName: UINT256_CAIRO_TYPE,
+				Type:     "struct",
+				Size:     2,
+				Members: map[string]any{
+					"low":  map[string]any{"cairo_type": "felt", "offset": float64(0)},
+					"high": map[string]any{"cairo_type": "felt", "offset": float64(1)},
+				},
+			},
+		},
+	}
+	runner, err := runners.NewCairoRunner(program, "plain", false)
+	if err != nil {
+		t.Errorf("NewCairoRunner error in test: %s", err)
+	}
+	return runner
+}
+
+func TestBuildArgsFromTypesUint256(t *testing.T) {
+	runner := newRunnerWithUint256Struct(t)
+	value := [2]lambdaworks.Felt{lambdaworks.FeltFromUint64(1), lambdaworks.FeltFromUint64(2)}
+	args, err := runner.BuildArgsFromTypes([]string{UINT256_CAIRO_TYPE}, []any{value})
+	if err != nil {
+		t.Errorf("BuildArgsFromTypes failed with error: %s", err)
+	}
+	expected := []memory.MaybeRelocatable{
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2)),
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Wrong args. Expected %v, got %v", expected, args)
+	}
+}
+
+func TestBuildArgsFromTypesUint256Array(t *testing.T) {
+	runner := newRunnerWithUint256Struct(t)
+	value := [][2]lambdaworks.Felt{
+		{lambdaworks.FeltFromUint64(1), lambdaworks.FeltFromUint64(2)},
+		{lambdaworks.FeltFromUint64(3), lambdaworks.FeltFromUint64(4)},
+	}
+	args, err := runner.BuildArgsFromTypes([]string{"felt", UINT256_CAIRO_TYPE + "*"}, []any{lambdaworks.FeltFromUint64(2), value})
+	if err != nil {
+		t.Errorf("BuildArgsFromTypes failed with error: %s", err)
+	}
+	expected := []memory.MaybeRelocatable{
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2)),
+		*memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(0, 0)),
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Wrong args. Expected %v, got %v", expected, args)
+	}
+	for i := uint(0); i < 4; i++ {
+		felt, err := runner.Vm.Segments.Memory.GetFelt(memory.NewRelocatable(0, i))
+		if err != nil || felt != lambdaworks.FeltFromUint64(uint64(i+1)) {
+			t.Errorf("Wrong array value at offset %d. Expected %d, got %v", i, i+1, felt)
+		}
+	}
+}
+
+func TestBuildArgsFromTypesUnknownType(t *testing.T) {
+	runner := newRunnerWithUint256Struct(t)
+	_, err := runner.BuildArgsFromTypes([]string{"Unknown"}, []any{lambdaworks.FeltZero()})
+	if err == nil {
+		t.Errorf("BuildArgsFromTypes should have failed with an unknown type")
+	}
+}
+
+func TestReadTypedReturnValuesUint256AndFeltArray(t *testing.T) {
+	runner := newRunnerWithUint256Struct(t)
+	runner.Vm.Segments.AddSegment()
+	runner.Vm.Segments.AddSegment()
+	// Return values: (value: Uint256, arr_len: felt, arr: felt*)
+	returnValues := []memory.MaybeRelocatable{
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(3)),
+		*memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 0)),
+	}
+	array := []memory.MaybeRelocatable{
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(8)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(9)),
+	}
+	runner.Vm.Segments.LoadData(memory.NewRelocatable(0, 0), &returnValues)
+	runner.Vm.Segments.LoadData(memory.NewRelocatable(1, 0), &array)
+	runner.Vm.RunContext.Ap = memory.NewRelocatable(0, 4)
+
+	values, err := runner.ReadTypedReturnValues([]string{UINT256_CAIRO_TYPE, "felt", "felt*"})
+	if err != nil {
+		t.Errorf("ReadTypedReturnValues failed with error: %s", err)
+	}
+	expected := []any{
+		[]any{lambdaworks.FeltFromUint64(1), lambdaworks.FeltFromUint64(2)},
+		lambdaworks.FeltFromUint64(3),
+		[]any{lambdaworks.FeltFromUint64(7), lambdaworks.FeltFromUint64(8), lambdaworks.FeltFromUint64(9)},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Wrong return values. Expected %v, got %v", expected, values)
+	}
+}
+
+func TestReadTypedReturnValuesPointerToStruct(t *testing.T) {
+	runner := newRunnerWithUint256Struct(t)
+	runner.Vm.Segments.AddSegment()
+	runner.Vm.Segments.AddSegment()
+	returnValues := []memory.MaybeRelocatable{*memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 0))}
+	value := []memory.MaybeRelocatable{
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(6)),
+	}
+	runner.Vm.Segments.LoadData(memory.NewRelocatable(0, 0), &returnValues)
+	runner.Vm.Segments.LoadData(memory.NewRelocatable(1, 0), &value)
+	runner.Vm.RunContext.Ap = memory.NewRelocatable(0, 1)
+
+	values, err := runner.ReadTypedReturnValues([]string{UINT256_CAIRO_TYPE + "*"})
+	if err != nil {
+		t.Errorf("ReadTypedReturnValues failed with error: %s", err)
+	}
+	expected := []any{[]any{lambdaworks.FeltFromUint64(5), lambdaworks.FeltFromUint64(6)}}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Wrong return values. Expected %v, got %v", expected, values)
+	}
+}
@@ -0,0 +1,46 @@
+package runners
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+var ErrCasmNoEntrypoint = errors.New("Casm contract class has no external entrypoints")
+
+// Creates a CairoRunner that runs the first external entrypoint of a compiled Cairo 1 contract class.
+// The program segment is loaded from the casm bytecode, and Initialize sets up the entrypoint's builtins
+// and call frame just like it does for the main function of a Cairo 0 program.
+// Casm hints are attached to the program, with the hint's json as code, but CairoVmHintProcessor doesn't execute them yet
+func NewCairoRunnerForCasm(casm parser.CasmContractClass, layout string) (*CairoRunner, error) {
+	if len(casm.EntryPointsByType.External) == 0 {
+		return nil, ErrCasmNoEntrypoint
+	}
+	entrypoint := casm.EntryPointsByType.External[0]
+
+	data := make([]memory.MaybeRelocatable, 0, len(casm.Bytecode))
+	for _, hexVal := range casm.Bytecode {
+		data = append(data, *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromHex(hexVal)))
+	}
+	program := vm.Program{
+		Data:        data,
+		Builtins:    entrypoint.Builtins,
+		Identifiers: make(map[string]vm.Identifier),
+	}
+	if len(casm.Hints) != 0 {
+		hints, err := vm.DeserializeHints(casm.Hints)
+		if err != nil {
+			return nil, err
+		}
+		program.Hints = hints
+	}
+
+	runner, err := NewCairoRunner(program, layout, false)
+	if err != nil {
+		return nil, err
+	}
+	runner.mainOffset = entrypoint.Offset
+	return runner, nil
+}
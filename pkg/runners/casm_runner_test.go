@@ -0,0 +1,136 @@
+package runners_test
+
+import (
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+)
+
+func TestCairoRunnerForCasmReturnsConstant(t *testing.T) {
+	casm := parser.CasmContractClass{
+		// [ap] = 5, ap++; ret
+		Bytecode: []string{"0x480680017fff8000", "0x5", "0x208b7fff7fff7ffe"},
+		EntryPointsByType: parser.CasmEntryPointsByType{
+			External: []parser.CasmEntryPoint{{Selector: "0x1", Offset: 0, Builtins: []string{}}},
+		},
+	}
+	runner, err := runners.NewCairoRunnerForCasm(casm, "plain")
+	if err != nil {
+		t.Errorf("NewCairoRunnerForCasm failed with error: %s", err)
+		return
+	}
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize failed with error: %s", err)
+		return
+	}
+	err = runner.RunUntilPC(end, &hints.CairoVmHintProcessor{})
+	if err != nil {
+		t.Errorf("RunUntilPC failed with error: %s", err)
+		return
+	}
+	resultAddr, _ := runner.Vm.RunContext.Ap.SubUint(1)
+	result, err := runner.Vm.Segments.Memory.GetFelt(resultAddr)
+	if err != nil || result != lambdaworks.FeltFromUint64(5) {
+		t.Errorf("Wrong return value. Expected 5, got %v", result)
+	}
+}
+
+func TestCairoRunnerForCasmNoEntrypoint(t *testing.T) {
+	_, err := runners.NewCairoRunnerForCasm(parser.CasmContractClass{}, "plain")
+	if err != runners.ErrCasmNoEntrypoint {
+		t.Errorf("Expected ErrCasmNoEntrypoint, got %v", err)
+	}
+}
+
+func TestCairoRunnerForCasmAttachesHints(t *testing.T) {
+	casm := parser.CasmContractClass{
+		Bytecode: []string{"0x480680017fff8000", "0x5", "0x208b7fff7fff7ffe"},
+		Hints:    []byte(`[[2, [{"AllocSegment": {"dst": {"register": "AP", "offset": 0}}}]]]`),
+		EntryPointsByType: parser.CasmEntryPointsByType{
+			External: []parser.CasmEntryPoint{{Selector: "0x1", Offset: 0, Builtins: []string{}}},
+		},
+	}
+	runner, err := runners.NewCairoRunnerForCasm(casm, "plain")
+	if err != nil {
+		t.Errorf("NewCairoRunnerForCasm failed with error: %s", err)
+		return
+	}
+	if len(runner.Program.Hints) != 1 || len(runner.Program.Hints[2]) != 1 {
+		t.Errorf("The casm hints should be attached to the program at offset 2, got %v", runner.Program.Hints)
+	}
+}
+
+// Casm class whose external entrypoint is at offset 3, after a function that returns 5
+func casmWithOffsetEntrypoint() parser.CasmContractClass {
+	return parser.CasmContractClass{
+		// [ap] = 5, ap++; ret; [ap] = 7, ap++; [ap] = 8, ap++; ret
+		Bytecode: []string{"0x480680017fff8000", "0x5", "0x208b7fff7fff7ffe", "0x480680017fff8000", "0x7", "0x480680017fff8000", "0x8", "0x208b7fff7fff7ffe"},
+		EntryPointsByType: parser.CasmEntryPointsByType{
+			External: []parser.CasmEntryPoint{{Selector: "0x1", Offset: 3, Builtins: []string{}}},
+		},
+	}
+}
+
+// Runs the runner's entrypoint until it returns, and returns its last return value
+func runCasmEntrypoint(t *testing.T, runner *runners.CairoRunner) lambdaworks.Felt {
+	end, err := runner.Initialize()
+	if err != nil {
+		t.Errorf("Initialize failed with error: %s", err)
+		return lambdaworks.Felt{}
+	}
+	err = runner.RunUntilPC(end, &hints.CairoVmHintProcessor{})
+	if err != nil {
+		t.Errorf("RunUntilPC failed with error: %s", err)
+		return lambdaworks.Felt{}
+	}
+	resultAddr, _ := runner.Vm.RunContext.Ap.SubUint(1)
+	result, _ := runner.Vm.Segments.Memory.GetFelt(resultAddr)
+	return result
+}
+
+func TestResetCasmRunnerKeepsEntrypoint(t *testing.T) {
+	runner, err := runners.NewCairoRunnerForCasm(casmWithOffsetEntrypoint(), "plain")
+	if err != nil {
+		t.Errorf("NewCairoRunnerForCasm failed with error: %s", err)
+		return
+	}
+	if result := runCasmEntrypoint(t, runner); result != lambdaworks.FeltFromUint64(8) {
+		t.Errorf("Wrong return value. Expected 8, got %v", result)
+	}
+	err = runner.EndRun(false, false, &runner.Vm, &hints.CairoVmHintProcessor{})
+	if err != nil {
+		t.Errorf("EndRun failed with error: %s", err)
+		return
+	}
+	err = runner.Reset()
+	if err != nil {
+		t.Errorf("Reset failed with error: %s", err)
+		return
+	}
+	if result := runCasmEntrypoint(t, runner); result != lambdaworks.FeltFromUint64(8) {
+		t.Errorf("Wrong return value after Reset. Expected 8, got %v", result)
+	}
+	if runner.Vm.CurrentStep != 3 {
+		t.Errorf("The run after Reset should start at the entrypoint. Expected 3 steps, got %d", runner.Vm.CurrentStep)
+	}
+}
+
+func TestDryRunStepCountCasmStartsAtEntrypoint(t *testing.T) {
+	runner, err := runners.NewCairoRunnerForCasm(casmWithOffsetEntrypoint(), "plain")
+	if err != nil {
+		t.Errorf("NewCairoRunnerForCasm failed with error: %s", err)
+		return
+	}
+	steps, err := runner.DryRunStepCount(&hints.CairoVmHintProcessor{}, 100)
+	if err != nil {
+		t.Errorf("DryRunStepCount failed with error: %s", err)
+		return
+	}
+	if steps != 3 {
+		t.Errorf("DryRunStepCount returned wrong amount of steps. Expected 3, got %d", steps)
+	}
+}
@@ -0,0 +1,120 @@
+package runners
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/starknet"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
+)
+
+var ErrSelectorNotFound = errors.New("No entry point matches the selector")
+
+var ErrRunnerAlreadyInitialized = errors.New("Cairo Runner was already initialized, Reset it before running another entry point")
+
+// Creates a CairoRunner for a Cairo 0 contract class, whose functions can be invoked with RunFromSelector
+func NewCairoRunnerForContractClass(contractClass starknet.ContractClass, layout string) (*CairoRunner, error) {
+	runner, err := NewCairoRunner(contractClass.Program, layout, false)
+	if err != nil {
+		return nil, err
+	}
+	entryPoints := contractClass.EntryPointsByType
+	runner.entryPoints = &entryPoints
+	return runner, nil
+}
+
+// Returns the offset of the entry point with the given selector, looking through every type of entry point
+func (r *CairoRunner) getEntryPointOffset(selector lambdaworks.Felt) (uint, error) {
+	if r.entryPoints != nil {
+		for _, entryPoints := range [][]starknet.EntryPoint{r.entryPoints.External, r.entryPoints.L1Handler, r.entryPoints.Constructor} {
+			for _, entryPoint := range entryPoints {
+				if entryPoint.Selector == selector {
+					return entryPoint.Offset, nil
+				}
+			}
+		}
+	}
+	return 0, errors.Wrapf(ErrSelectorNotFound, "selector %s", selector.ToHexString())
+}
+
+// Initializes the runner and runs the contract function with the given selector until it returns, like a
+// Starknet contract call. The function is called with the contract calling convention: a syscall pointer
+// followed by the builtins' initial stack as implicit arguments, then the calldata size and a pointer to the calldata.
+// Returns the retdata, read from the (retdata_size, retdata) pair the function returns last.
+// Fails with ErrSelectorNotFound if the runner wasn't created by NewCairoRunnerForContractClass or the
+// contract has no entry point with that selector.
+// Each call needs a fresh runner: it fails with ErrRunnerAlreadyInitialized if the runner was already initialized,
+// in which case EndRun and Reset have to be called before running another entry point
+func (r *CairoRunner) RunFromSelector(selector lambdaworks.Felt, calldata []lambdaworks.Felt, hintProcessor vm.HintProcessor) ([]lambdaworks.Felt, error) {
+	if r.Vm.Segments.Memory.NumSegments() != 0 {
+		return nil, ErrRunnerAlreadyInitialized
+	}
+	entrypoint, err := r.getEntryPointOffset(selector)
+	if err != nil {
+		return nil, err
+	}
+	if entrypoint >= uint(len(r.Program.Data)) {
+		return nil, errors.Errorf("Entrypoint %d is out of the program's bounds", entrypoint)
+	}
+	err = r.initializeBuiltins()
+	if err != nil {
+		return nil, err
+	}
+	err = r.initializeSegments()
+	if err != nil {
+		return nil, err
+	}
+
+	syscallPtr, err := r.Vm.Segments.AddSegment()
+	if err != nil {
+		return nil, err
+	}
+	calldataPtr, err := r.Vm.Segments.AddSegment()
+	if err != nil {
+		return nil, err
+	}
+	calldataValues := make([]memory.MaybeRelocatable, 0, len(calldata))
+	for _, value := range calldata {
+		calldataValues = append(calldataValues, *memory.NewMaybeRelocatableFelt(value))
+	}
+	_, err = r.Vm.Segments.LoadData(calldataPtr, &calldataValues)
+	if err != nil {
+		return nil, err
+	}
+
+	stack := []memory.MaybeRelocatable{*memory.NewMaybeRelocatableRelocatable(syscallPtr)}
+	for _, builtin := range r.Vm.BuiltinRunners {
+		stack = append(stack, builtin.InitialStack()...)
+	}
+	stack = append(stack,
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(len(calldata)))),
+		*memory.NewMaybeRelocatableRelocatable(calldataPtr),
+	)
+
+	end, err := r.initializeFunctionCall(entrypoint, stack)
+	if err != nil {
+		return nil, err
+	}
+	err = r.RunUntilPC(end, hintProcessor)
+	if err != nil {
+		return nil, err
+	}
+
+	retdataSizeAddr, err := r.Vm.RunContext.Ap.SubUint(2)
+	if err != nil {
+		return nil, err
+	}
+	retdataSize, err := r.Vm.Segments.Memory.GetFelt(retdataSizeAddr)
+	if err != nil {
+		return nil, err
+	}
+	size, err := retdataSize.ToU64()
+	if err != nil {
+		return nil, err
+	}
+	retdataPtr, err := r.Vm.Segments.Memory.GetRelocatable(retdataSizeAddr.AddUint(1))
+	if err != nil {
+		return nil, err
+	}
+	return r.Vm.Segments.Memory.GetFeltRange(retdataPtr, uint(size))
+}
@@ -0,0 +1,99 @@
+package runners_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/hints"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
+	"github.com/lambdaclass/cairo-vm.go/pkg/starknet"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func echoContractClass() starknet.ContractClass {
+	// Contract function returning its calldata:
+	// [ap] = [fp - 5], ap++ (syscall_ptr); [ap] = [fp - 4], ap++ (calldata_size); [ap] = [fp - 3], ap++ (calldata); ret
+	programData := []memory.MaybeRelocatable{
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480a7ffb7fff8000)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480a7ffc7fff8000)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480a7ffd7fff8000)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x208b7fff7fff7ffe)),
+	}
+	return starknet.ContractClass{
+		Program: vm.Program{Data: programData, Identifiers: make(map[string]vm.Identifier)},
+		EntryPointsByType: starknet.EntryPointsByType{
+			External: []starknet.EntryPoint{{Selector: lambdaworks.FeltOne(), Offset: 0}},
+		},
+	}
+}
+
+func TestRunFromSelectorReturnsRetdata(t *testing.T) {
+	runner, err := runners.NewCairoRunnerForContractClass(echoContractClass(), "plain")
+	if err != nil {
+		t.Errorf("NewCairoRunnerForContractClass failed with error: %s", err)
+		return
+	}
+	calldata := []lambdaworks.Felt{lambdaworks.FeltFromUint64(5), lambdaworks.FeltFromUint64(7)}
+	retdata, err := runner.RunFromSelector(lambdaworks.FeltOne(), calldata, &hints.CairoVmHintProcessor{})
+	if err != nil {
+		t.Errorf("RunFromSelector failed with error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(retdata, calldata) {
+		t.Errorf("Wrong retdata. Expected %v, got %v", calldata, retdata)
+	}
+}
+
+func TestRunFromSelectorUnknownSelector(t *testing.T) {
+	runner, err := runners.NewCairoRunnerForContractClass(echoContractClass(), "plain")
+	if err != nil {
+		t.Errorf("NewCairoRunnerForContractClass failed with error: %s", err)
+		return
+	}
+	_, err = runner.RunFromSelector(lambdaworks.FeltFromUint64(2), nil, &hints.CairoVmHintProcessor{})
+	if !errors.Is(err, runners.ErrSelectorNotFound) {
+		t.Errorf("RunFromSelector should have failed with ErrSelectorNotFound, got %v", err)
+	}
+}
+
+func TestRunFromSelectorTwiceRequiresReset(t *testing.T) {
+	runner, err := runners.NewCairoRunnerForContractClass(echoContractClass(), "plain")
+	if err != nil {
+		t.Errorf("NewCairoRunnerForContractClass failed with error: %s", err)
+		return
+	}
+	hintProcessor := &hints.CairoVmHintProcessor{}
+	_, err = runner.RunFromSelector(lambdaworks.FeltOne(), []lambdaworks.Felt{lambdaworks.FeltFromUint64(5)}, hintProcessor)
+	if err != nil {
+		t.Errorf("RunFromSelector failed with error: %s", err)
+		return
+	}
+	_, err = runner.RunFromSelector(lambdaworks.FeltOne(), nil, hintProcessor)
+	if !errors.Is(err, runners.ErrRunnerAlreadyInitialized) {
+		t.Errorf("A second RunFromSelector should fail with ErrRunnerAlreadyInitialized, got %v", err)
+		return
+	}
+
+	err = runner.EndRun(false, false, &runner.Vm, hintProcessor)
+	if err != nil {
+		t.Errorf("EndRun failed with error: %s", err)
+		return
+	}
+	err = runner.Reset()
+	if err != nil {
+		t.Errorf("Reset failed with error: %s", err)
+		return
+	}
+	calldata := []lambdaworks.Felt{lambdaworks.FeltFromUint64(9)}
+	retdata, err := runner.RunFromSelector(lambdaworks.FeltOne(), calldata, hintProcessor)
+	if err != nil {
+		t.Errorf("RunFromSelector after Reset failed with error: %s", err)
+		return
+	}
+	if !reflect.DeepEqual(retdata, calldata) {
+		t.Errorf("Wrong retdata after Reset. Expected %v, got %v", calldata, retdata)
+	}
+}
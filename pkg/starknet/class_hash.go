@@ -0,0 +1,227 @@
+package starknet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"unicode/utf16"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/starknet_crypto"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/pkg/errors"
+)
+
+// Version of the Cairo 0 class hash algorithm, hashed along with the class
+const CLASS_HASH_API_VERSION = 0
+
+// An entry point of a Cairo 0 contract class: the selector of the function and its offset in the program data
+type EntryPoint struct {
+	Selector lambdaworks.Felt
+	Offset   uint
+}
+
+// The entry points of a Cairo 0 contract class, grouped by type
+type EntryPointsByType struct {
+	External    []EntryPoint
+	L1Handler   []EntryPoint
+	Constructor []EntryPoint
+}
+
+// Computes the hash of a Cairo 0 (deprecated) contract class, which identifies it once declared.
+// It is the Pedersen chain of the api version, the hashes of each type of entry points, the builtins, the hinted
+// class hash and the program data.
+// The hinted class hash covers the hints, identifiers and abi of the class, which the parsed program doesn't keep
+// in their original form, so it is taken from program.HintedClassHash. ComputeClassHashFromJson fills it when
+// hashing a compiled class json.
+// Fails if the program has no hinted class hash, if a builtin name doesn't fit in a felt or if the program data
+// holds relocatable values
+func ComputeClassHash(program vm.Program, entryPoints EntryPointsByType) (lambdaworks.Felt, error) {
+	if program.HintedClassHash.IsZero() {
+		return lambdaworks.Felt{}, errors.New("Contract class program has no hinted class hash")
+	}
+	builtins := make([]lambdaworks.Felt, 0, len(program.Builtins))
+	for _, builtin := range program.Builtins {
+		// Builtin names are encoded as cairo short strings
+		encodedBuiltin, err := lambdaworks.FeltFromBeBytesSlice([]byte(builtin))
+		if err != nil {
+			return lambdaworks.Felt{}, errors.Wrapf(err, "Invalid builtin name %s", builtin)
+		}
+		builtins = append(builtins, encodedBuiltin)
+	}
+
+	data := make([]lambdaworks.Felt, 0, len(program.Data))
+	for i, entry := range program.Data {
+		felt, ok := entry.GetFelt()
+		if !ok {
+			return lambdaworks.Felt{}, errors.Errorf("Contract class program data holds a relocatable value at position %d", i)
+		}
+		data = append(data, felt)
+	}
+
+	return starknet_crypto.PedersenHashArray([]lambdaworks.Felt{
+		lambdaworks.FeltFromUint64(CLASS_HASH_API_VERSION),
+		hashEntryPoints(entryPoints.External),
+		hashEntryPoints(entryPoints.L1Handler),
+		hashEntryPoints(entryPoints.Constructor),
+		starknet_crypto.PedersenHashArray(builtins),
+		program.HintedClassHash,
+		starknet_crypto.PedersenHashArray(data),
+	}), nil
+}
+
+// Returns the Pedersen chain of the selector and offset of each entry point
+func hashEntryPoints(entryPoints []EntryPoint) lambdaworks.Felt {
+	flattened := make([]lambdaworks.Felt, 0, 2*len(entryPoints))
+	for _, entryPoint := range entryPoints {
+		flattened = append(flattened, entryPoint.Selector, lambdaworks.FeltFromUint64(uint64(entryPoint.Offset)))
+	}
+	return starknet_crypto.PedersenHashArray(flattened)
+}
+
+// Computes the hash of a Cairo 0 contract class from its compiled json, as output by starknet-compile-deprecated.
+// Fails if the json isn't a valid contract class, or like ComputeClassHash
+func ComputeClassHashFromJson(contractClassJson []byte) (lambdaworks.Felt, error) {
+	var contractClass parser.ContractClassJson
+	if err := json.Unmarshal(contractClassJson, &contractClass); err != nil {
+		return lambdaworks.Felt{}, parser.ParserError(err)
+	}
+	class, err := DeserializeContractClass(contractClass)
+	if err != nil {
+		return lambdaworks.Felt{}, err
+	}
+	class.Program.HintedClassHash, err = ComputeHintedClassHash(contractClassJson)
+	if err != nil {
+		return lambdaworks.Felt{}, err
+	}
+	return ComputeClassHash(class.Program, class.EntryPointsByType)
+}
+
+// Computes the hinted class hash of a Cairo 0 contract class from its compiled json, following cairo-lang's
+// compute_hinted_class_hash: the starknet keccak of the program without its debug info and the abi, serialized
+// like python's json.dumps with sorted keys.
+// cairo-lang serializes the program it parsed, while this serializes the program as it appears in the json.
+// Both match for classes compiled by starknet-compile-deprecated, which outputs that same serialization.
+// Fails if the json isn't an object with a program object
+func ComputeHintedClassHash(contractClassJson []byte) (lambdaworks.Felt, error) {
+	decoder := json.NewDecoder(bytes.NewReader(contractClassJson))
+	// Numbers are kept as written, as python doesn't reformat integers
+	decoder.UseNumber()
+	var contractClass map[string]any
+	if err := decoder.Decode(&contractClass); err != nil {
+		return lambdaworks.Felt{}, parser.ParserError(err)
+	}
+	program, ok := contractClass["program"].(map[string]any)
+	if !ok {
+		return lambdaworks.Felt{}, errors.New("Contract class json has no program object")
+	}
+
+	program["debug_info"] = nil
+	// Fields removed by cairo-lang to keep the hash of classes compiled before they were added
+	attributes, _ := program["attributes"].([]any)
+	if len(attributes) == 0 {
+		delete(program, "attributes")
+	}
+	for _, attribute := range attributes {
+		attribute, ok := attribute.(map[string]any)
+		if !ok {
+			continue
+		}
+		if scopes, _ := attribute["accessible_scopes"].([]any); len(scopes) == 0 {
+			delete(attribute, "accessible_scopes")
+		}
+		if attribute["flow_tracking_data"] == nil {
+			delete(attribute, "flow_tracking_data")
+		}
+	}
+
+	var serialized bytes.Buffer
+	writePythonJson(&serialized, map[string]any{"program": program, "abi": contractClass["abi"]})
+	return StarknetKeccak(serialized.Bytes()), nil
+}
+
+// Returns the keccak256 of data truncated to its 250 lower bits, as used for selectors and hinted class hashes
+func StarknetKeccak(data []byte) lambdaworks.Felt {
+	hash := builtins.Keccak256(data)
+	// Keep the 250 lower bits of the big endian hash
+	hash[0] &= 0x03
+	return lambdaworks.FeltFromBeBytes(&hash)
+}
+
+// Writes a value decoded from json the way python's json.dumps(value, sort_keys=True) does: keys are sorted,
+// items are separated by ", " and keys by ": ", and non ascii characters are escaped
+func writePythonJson(buffer *bytes.Buffer, value any) {
+	switch value := value.(type) {
+	case nil:
+		buffer.WriteString("null")
+	case bool:
+		if value {
+			buffer.WriteString("true")
+		} else {
+			buffer.WriteString("false")
+		}
+	case json.Number:
+		buffer.WriteString(value.String())
+	case string:
+		writePythonJsonString(buffer, value)
+	case []any:
+		buffer.WriteByte('[')
+		for i, item := range value {
+			if i > 0 {
+				buffer.WriteString(", ")
+			}
+			writePythonJson(buffer, item)
+		}
+		buffer.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buffer.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buffer.WriteString(", ")
+			}
+			writePythonJsonString(buffer, key)
+			buffer.WriteString(": ")
+			writePythonJson(buffer, value[key])
+		}
+		buffer.WriteByte('}')
+	}
+}
+
+func writePythonJsonString(buffer *bytes.Buffer, value string) {
+	buffer.WriteByte('"')
+	for _, r := range value {
+		switch {
+		case r == '"':
+			buffer.WriteString(`\"`)
+		case r == '\\':
+			buffer.WriteString(`\\`)
+		case r == '\n':
+			buffer.WriteString(`\n`)
+		case r == '\r':
+			buffer.WriteString(`\r`)
+		case r == '\t':
+			buffer.WriteString(`\t`)
+		case r == '\b':
+			buffer.WriteString(`\b`)
+		case r == '\f':
+			buffer.WriteString(`\f`)
+		case r < 0x20 || (r > 0x7e && r < 0x10000):
+			fmt.Fprintf(buffer, `\u%04x`, r)
+		case r >= 0x10000:
+			// Characters outside the basic multilingual plane are escaped as utf-16 surrogate pairs
+			high, low := utf16.EncodeRune(r)
+			fmt.Fprintf(buffer, `\u%04x\u%04x`, high, low)
+		default:
+			buffer.WriteRune(r)
+		}
+	}
+	buffer.WriteByte('"')
+}
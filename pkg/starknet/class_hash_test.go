@@ -0,0 +1,187 @@
+package starknet_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/starknet"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func classHashTestProgram() vm.Program {
+	return vm.Program{
+		Data: []memory.MaybeRelocatable{
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromHex("0x40780017fff7fff")),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromHex("0x1")),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromHex("0x208b7fff7fff7ffe")),
+		},
+		Builtins:        []string{"pedersen", "range_check"},
+		HintedClassHash: lambdaworks.FeltFromHex("0x1234"),
+	}
+}
+
+func classHashTestEntryPoints() starknet.EntryPointsByType {
+	return starknet.EntryPointsByType{
+		External: []starknet.EntryPoint{
+			{Selector: lambdaworks.FeltFromHex("0x362398bec32bc0ebb411203221a35a0301193a96f317ebe5e40be9f60d15320"), Offset: 0},
+		},
+		Constructor: []starknet.EntryPoint{
+			{Selector: lambdaworks.FeltFromHex("0x28ffe4ff0f226a9107253e17a904099aa4f63a02a5621de0576e5aa71bc5194"), Offset: 2},
+		},
+	}
+}
+
+func TestComputeClassHash(t *testing.T) {
+	hash, err := starknet.ComputeClassHash(classHashTestProgram(), classHashTestEntryPoints())
+	if err != nil {
+		t.Errorf("ComputeClassHash failed with error: %s", err)
+		return
+	}
+	// Computed with an independent port of the cairo-lang deprecated class hash algorithm
+	expected := lambdaworks.FeltFromHex("0x56abb5d7c5fa0562c35d98a6bbe770477a786690b12c80d4e7a552c4d507ed9")
+	if hash != expected {
+		t.Errorf("Wrong class hash. Expected %s, got %s", expected.ToHexString(), hash.ToHexString())
+	}
+}
+
+func TestComputeClassHashRelocatableData(t *testing.T) {
+	program := classHashTestProgram()
+	program.Data = append(program.Data, *memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 0)))
+	_, err := starknet.ComputeClassHash(program, classHashTestEntryPoints())
+	if err == nil {
+		t.Errorf("ComputeClassHash should have failed with relocatable program data")
+	}
+}
+
+func TestComputeClassHashNoHintedClassHash(t *testing.T) {
+	program := classHashTestProgram()
+	program.HintedClassHash = lambdaworks.FeltZero()
+	_, err := starknet.ComputeClassHash(program, classHashTestEntryPoints())
+	if err == nil {
+		t.Errorf("ComputeClassHash should have failed without a hinted class hash")
+	}
+}
+
+// A Cairo 0 contract class as output by starknet-compile-deprecated, including an attribute with the fields
+// cairo-lang drops from the hinted class hash, escaped characters and a non ascii character
+const classHashTestContractJson = `{
+	"abi": [
+		{
+			"inputs": [{"name": "amount", "type": "felt"}],
+			"name": "increase_balance",
+			"outputs": [],
+			"type": "function"
+		}
+	],
+	"entry_points_by_type": {
+		"CONSTRUCTOR": [],
+		"EXTERNAL": [
+			{"offset": "0x3", "selector": "0x362398bec32bc0ebb411203221a35a0301193a96f317ebe5e40be9f60d15320"}
+		],
+		"L1_HANDLER": []
+	},
+	"program": {
+		"attributes": [
+			{
+				"accessible_scopes": [],
+				"end_pc": 5,
+				"flow_tracking_data": null,
+				"name": "error_message",
+				"start_pc": 3,
+				"value": "Amount must be positive – got <zero>"
+			}
+		],
+		"builtins": ["pedersen", "range_check"],
+		"compiler_version": "0.10.3",
+		"data": [
+			"0x40780017fff7fff",
+			"0x1",
+			"0x208b7fff7fff7ffe",
+			"0x480680017fff8000",
+			"0x0",
+			"0x208b7fff7fff7ffe"
+		],
+		"debug_info": {"file_contents": {}, "instruction_locations": {}},
+		"hints": {
+			"3": [
+				{
+					"accessible_scopes": ["__main__", "__main__.increase_balance"],
+					"code": "memory[ap] = to_felt_or_relocatable(ids.amount > 0)\n# \"checked\"",
+					"flow_tracking_data": {"ap_tracking": {"group": 0, "offset": 0}, "reference_ids": {}}
+				}
+			]
+		},
+		"identifiers": {
+			"__main__.increase_balance": {"decorators": ["external"], "pc": 3, "type": "function"}
+		},
+		"main_scope": "__main__",
+		"prime": "0x800000000000011000000000000000000000000000000000000000000000001",
+		"reference_manager": {"references": []}
+	}
+}`
+
+func TestStarknetKeccakSelectors(t *testing.T) {
+	selectors := map[string]string{
+		"increase_balance": "0x362398bec32bc0ebb411203221a35a0301193a96f317ebe5e40be9f60d15320",
+		"transfer":         "0x83afd3f4caedc6eebf44246fe54e38c95e3179a5ec9ea81740eca5b482d12e",
+	}
+	for name, selector := range selectors {
+		hash := starknet.StarknetKeccak([]byte(name))
+		if hash != lambdaworks.FeltFromHex(selector) {
+			t.Errorf("Wrong selector for %s. Expected %s, got %s", name, selector, hash.ToHexString())
+		}
+	}
+}
+
+func TestComputeHintedClassHash(t *testing.T) {
+	hash, err := starknet.ComputeHintedClassHash([]byte(classHashTestContractJson))
+	if err != nil {
+		t.Errorf("ComputeHintedClassHash failed with error: %s", err)
+		return
+	}
+	// Starknet keccak of the contract serialized by python's json.dumps after cairo-lang's compute_hinted_class_hash
+	// transformations
+	expected := lambdaworks.FeltFromHex("0x3e6f2eb17efcf4a39aad7bdef36b43c082d77126a33e64cc92d075492f58423")
+	if hash != expected {
+		t.Errorf("Wrong hinted class hash. Expected %s, got %s", expected.ToHexString(), hash.ToHexString())
+	}
+}
+
+func TestComputeHintedClassHashIgnoresDebugInfo(t *testing.T) {
+	withoutDebugInfo := strings.Replace(classHashTestContractJson, `{"file_contents": {}, "instruction_locations": {}}`, "null", 1)
+	hash, err := starknet.ComputeHintedClassHash([]byte(classHashTestContractJson))
+	if err != nil {
+		t.Errorf("ComputeHintedClassHash failed with error: %s", err)
+		return
+	}
+	otherHash, err := starknet.ComputeHintedClassHash([]byte(withoutDebugInfo))
+	if err != nil {
+		t.Errorf("ComputeHintedClassHash failed with error: %s", err)
+		return
+	}
+	if hash != otherHash {
+		t.Errorf("The debug info should not affect the hinted class hash")
+	}
+}
+
+func TestComputeHintedClassHashNoProgram(t *testing.T) {
+	_, err := starknet.ComputeHintedClassHash([]byte(`{"abi": []}`))
+	if err == nil {
+		t.Errorf("ComputeHintedClassHash should have failed without a program")
+	}
+}
+
+func TestComputeClassHashFromJson(t *testing.T) {
+	hash, err := starknet.ComputeClassHashFromJson([]byte(classHashTestContractJson))
+	if err != nil {
+		t.Errorf("ComputeClassHashFromJson failed with error: %s", err)
+		return
+	}
+	// Computed with an independent port of the cairo-lang deprecated class hash algorithm
+	expected := lambdaworks.FeltFromHex("0x706a87510e856d560cd7e5473dfb30f531345c4d0057ea7ef39179124dea0cc")
+	if hash != expected {
+		t.Errorf("Wrong class hash. Expected %s, got %s", expected.ToHexString(), hash.ToHexString())
+	}
+}
@@ -0,0 +1,63 @@
+package starknet
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/pkg/errors"
+)
+
+// A Cairo 0 contract class: its program and the entry points that can be invoked
+type ContractClass struct {
+	Program           vm.Program
+	EntryPointsByType EntryPointsByType
+}
+
+// Builds a ContractClass from its compiled json.
+// Fails if the program can't be deserialized or if an entry point has a malformed selector or offset
+func DeserializeContractClass(contractClass parser.ContractClassJson) (ContractClass, error) {
+	program, err := vm.DeserializeProgramJson(contractClass.Program)
+	if err != nil {
+		return ContractClass{}, err
+	}
+
+	var entryPoints EntryPointsByType
+	entryPoints.External, err = deserializeEntryPoints(contractClass.EntryPointsByType.External)
+	if err != nil {
+		return ContractClass{}, errors.Wrap(err, "Invalid EXTERNAL entry point")
+	}
+	entryPoints.L1Handler, err = deserializeEntryPoints(contractClass.EntryPointsByType.L1Handler)
+	if err != nil {
+		return ContractClass{}, errors.Wrap(err, "Invalid L1_HANDLER entry point")
+	}
+	entryPoints.Constructor, err = deserializeEntryPoints(contractClass.EntryPointsByType.Constructor)
+	if err != nil {
+		return ContractClass{}, errors.Wrap(err, "Invalid CONSTRUCTOR entry point")
+	}
+
+	return ContractClass{Program: program, EntryPointsByType: entryPoints}, nil
+}
+
+func deserializeEntryPoints(entryPoints []parser.ContractEntryPoint) ([]EntryPoint, error) {
+	result := make([]EntryPoint, 0, len(entryPoints))
+	for _, entryPoint := range entryPoints {
+		selector, ok := new(big.Int).SetString(trimHexPrefix(entryPoint.Selector), 16)
+		if !ok || selector.Sign() < 0 {
+			return nil, errors.Errorf("Malformed selector %s", entryPoint.Selector)
+		}
+		offset, err := strconv.ParseUint(trimHexPrefix(entryPoint.Offset), 16, 64)
+		if err != nil {
+			return nil, errors.Errorf("Malformed offset %s", entryPoint.Offset)
+		}
+		result = append(result, EntryPoint{Selector: lambdaworks.FeltFromBigInt(selector), Offset: uint(offset)})
+	}
+	return result, nil
+}
+
+func trimHexPrefix(value string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+}
@@ -0,0 +1,73 @@
+package starknet_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/starknet"
+)
+
+const testContractClassJson = `{
+	"abi": [],
+	"entry_points_by_type": {
+		"CONSTRUCTOR": [],
+		"EXTERNAL": [
+			{"offset": "0x3a", "selector": "0x362398bec32bc0ebb411203221a35a0301193a96f317ebe5e40be9f60d15320"},
+			{"offset": "0x5b", "selector": "0x39e11d48192e4333233c7eb19d10ad67c362bb28580c604d67884c85da39695"}
+		],
+		"L1_HANDLER": []
+	},
+	"program": {
+		"builtins": ["pedersen", "range_check"],
+		"data": ["0x40780017fff7fff", "0x1", "0x208b7fff7fff7ffe"],
+		"hints": {},
+		"identifiers": {},
+		"main_scope": "__main__",
+		"prime": "0x800000000000011000000000000000000000000000000000000000000000001",
+		"reference_manager": {"references": []}
+	}
+}`
+
+func TestDeserializeContractClassExternalEntryPoints(t *testing.T) {
+	var contractClassJson parser.ContractClassJson
+	err := json.Unmarshal([]byte(testContractClassJson), &contractClassJson)
+	if err != nil {
+		t.Errorf("Unmarshal error in test: %s", err)
+		return
+	}
+	contractClass, err := starknet.DeserializeContractClass(contractClassJson)
+	if err != nil {
+		t.Errorf("DeserializeContractClass failed with error: %s", err)
+		return
+	}
+
+	expected := []starknet.EntryPoint{
+		{Selector: lambdaworks.FeltFromHex("0x362398bec32bc0ebb411203221a35a0301193a96f317ebe5e40be9f60d15320"), Offset: 0x3a},
+		{Selector: lambdaworks.FeltFromHex("0x39e11d48192e4333233c7eb19d10ad67c362bb28580c604d67884c85da39695"), Offset: 0x5b},
+	}
+	if !reflect.DeepEqual(contractClass.EntryPointsByType.External, expected) {
+		t.Errorf("Wrong external entry points. Expected %+v, got %+v", expected, contractClass.EntryPointsByType.External)
+	}
+	if len(contractClass.EntryPointsByType.L1Handler) != 0 || len(contractClass.EntryPointsByType.Constructor) != 0 {
+		t.Errorf("Expected no L1_HANDLER nor CONSTRUCTOR entry points")
+	}
+	if !reflect.DeepEqual(contractClass.Program.Builtins, []string{"pedersen", "range_check"}) || len(contractClass.Program.Data) != 3 {
+		t.Errorf("Contract class program wasn't deserialized")
+	}
+}
+
+func TestDeserializeContractClassMalformedOffset(t *testing.T) {
+	contractClassJson := parser.ContractClassJson{
+		EntryPointsByType: parser.ContractEntryPointsByType{
+			External: []parser.ContractEntryPoint{{Selector: "0x1", Offset: "not an offset"}},
+		},
+		Program: parser.CompiledJson{Prime: "0x800000000000011000000000000000000000000000000000000000000000001"},
+	}
+	_, err := starknet.DeserializeContractClass(contractClassJson)
+	if err == nil {
+		t.Errorf("DeserializeContractClass should have failed with a malformed offset")
+	}
+}
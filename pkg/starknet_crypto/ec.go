@@ -0,0 +1,96 @@
+package starknet_crypto
+
+import (
+	"math/big"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+)
+
+// The STARK curve is y^2 = x^3 + ALPHA * x + BETA over the cairo prime field
+const STARK_CURVE_BETA_HEX = "0x6f21413efbe40de150e596d72f7a8c5609ad26c15c915c1f4cdfcb99cee9e89"
+const STARK_CURVE_GENERATOR_X_HEX = "0x1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca"
+const STARK_CURVE_GENERATOR_Y_HEX = "0x5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f"
+
+// A point of the STARK curve in affine coordinates. The point at infinity (the identity) has Infinity set
+type AffinePoint struct {
+	X        lambdaworks.Felt
+	Y        lambdaworks.Felt
+	Infinity bool
+}
+
+func NewAffinePoint(x lambdaworks.Felt, y lambdaworks.Felt) AffinePoint {
+	return AffinePoint{X: x, Y: y}
+}
+
+// Returns the generator of the STARK curve
+func StarkCurveGenerator() AffinePoint {
+	return NewAffinePoint(lambdaworks.FeltFromHex(STARK_CURVE_GENERATOR_X_HEX), lambdaworks.FeltFromHex(STARK_CURVE_GENERATOR_Y_HEX))
+}
+
+func starkCurveAlpha() lambdaworks.Felt {
+	return lambdaworks.FeltOne()
+}
+
+func starkCurveBeta() lambdaworks.Felt {
+	return lambdaworks.FeltFromHex(STARK_CURVE_BETA_HEX)
+}
+
+// Returns true if the point satisfies the curve's equation. The point at infinity is on the curve
+func (p AffinePoint) IsOnCurve() bool {
+	if p.Infinity {
+		return true
+	}
+	rhs := p.X.PowUint(3).Add(starkCurveAlpha().Mul(p.X)).Add(starkCurveBeta())
+	return p.Y.Mul(p.Y) == rhs
+}
+
+// Returns p + q
+func (p AffinePoint) Add(q AffinePoint) AffinePoint {
+	if p.Infinity {
+		return q
+	}
+	if q.Infinity {
+		return p
+	}
+	if p.X == q.X {
+		if p.Y == q.Y {
+			return p.Double()
+		}
+		// q is -p
+		return AffinePoint{Infinity: true}
+	}
+	slope := q.Y.Sub(p.Y).Div(q.X.Sub(p.X))
+	return p.pointFromSlope(slope, q.X)
+}
+
+// Returns p + p
+func (p AffinePoint) Double() AffinePoint {
+	if p.Infinity || p.Y.IsZero() {
+		return AffinePoint{Infinity: true}
+	}
+	three := lambdaworks.FeltFromUint64(3)
+	two := lambdaworks.FeltFromUint64(2)
+	slope := three.Mul(p.X).Mul(p.X).Add(starkCurveAlpha()).Div(two.Mul(p.Y))
+	return p.pointFromSlope(slope, p.X)
+}
+
+// Returns the third intersection of the line through p with the given slope, reflected over the x axis,
+// where otherX is the x coordinate of the second point of the line
+func (p AffinePoint) pointFromSlope(slope lambdaworks.Felt, otherX lambdaworks.Felt) AffinePoint {
+	x := slope.Mul(slope).Sub(p.X).Sub(otherX)
+	y := slope.Mul(p.X.Sub(x)).Sub(p.Y)
+	return NewAffinePoint(x, y)
+}
+
+// Returns scalar * p using double-and-add. The scalar must be non-negative,
+// felts can be converted with lambdaworks.Felt.ToStarkCurveScalar
+func (p AffinePoint) ScalarMul(scalar *big.Int) AffinePoint {
+	result := AffinePoint{Infinity: true}
+	for i := scalar.BitLen() - 1; i >= 0; i-- {
+		result = result.Double()
+		if scalar.Bit(i) == 1 {
+			result = result.Add(p)
+		}
+	}
+	return result
+}
@@ -0,0 +1,55 @@
+package starknet_crypto_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	starknet_crypto "github.com/lambdaclass/cairo-vm.go/pkg/starknet_crypto"
+)
+
+func TestGeneratorIsOnCurve(t *testing.T) {
+	if !starknet_crypto.StarkCurveGenerator().IsOnCurve() {
+		t.Errorf("The generator should be on the curve")
+	}
+	notOnCurve := starknet_crypto.NewAffinePoint(lambdaworks.FeltOne(), lambdaworks.FeltOne())
+	if notOnCurve.IsOnCurve() {
+		t.Errorf("(1, 1) should not be on the curve")
+	}
+}
+
+func TestAddGeneratorToItselfEqualsDouble(t *testing.T) {
+	g := starknet_crypto.StarkCurveGenerator()
+	sum := g.Add(g)
+	double := g.Double()
+	if sum != double {
+		t.Errorf("G + G should equal 2 * G. Got %+v and %+v", sum, double)
+	}
+	if scalarMul := g.ScalarMul(big.NewInt(2)); scalarMul != double {
+		t.Errorf("ScalarMul(G, 2) should equal 2 * G. Got %+v and %+v", scalarMul, double)
+	}
+	expected := starknet_crypto.NewAffinePoint(
+		lambdaworks.FeltFromHex("0x759ca09377679ecd535a81e83039658bf40959283187c654c5416f439403cf5"),
+		lambdaworks.FeltFromHex("0x6f524a3400e7708d5c01a28598ad272e7455aa88778b19f93b562d7a9646c41"),
+	)
+	if double != expected || !double.IsOnCurve() {
+		t.Errorf("Wrong 2 * G. Expected %+v, got %+v", expected, double)
+	}
+}
+
+func TestScalarMulPublicKey(t *testing.T) {
+	// Public key (x coordinate) of a known private key
+	privateKey := lambdaworks.FeltFromHex("0x3c1e9550e66958296d11b60f8e8e7a7ad990d07fa65d5f7652c4a6c87d4e3cc")
+	publicKey := starknet_crypto.StarkCurveGenerator().ScalarMul(privateKey.ToStarkCurveScalar())
+	expectedX := lambdaworks.FeltFromHex("0x77a3b314db07c45076d11f62b6f9e748a39790441823307743cf00d6597ea43")
+	if publicKey.X != expectedX || !publicKey.IsOnCurve() {
+		t.Errorf("Wrong public key. Expected x %v, got %+v", expectedX, publicKey)
+	}
+}
+
+func TestScalarMulByCurveOrderIsInfinity(t *testing.T) {
+	order, _ := new(big.Int).SetString(lambdaworks.STARK_CURVE_ORDER_HEX, 0)
+	if !starknet_crypto.StarkCurveGenerator().ScalarMul(order).Infinity {
+		t.Errorf("Multiplying the generator by the curve's order should give the point at infinity")
+	}
+}
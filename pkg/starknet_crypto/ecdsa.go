@@ -0,0 +1,208 @@
+package starknet_crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/pkg/errors"
+)
+
+var ErrInvalidSignatureInput = errors.New("Invalid ECDSA input")
+
+var ErrInvalidNonce = errors.New("Nonce yields an invalid ECDSA signature")
+
+// Amount of RFC 6979 nonces tried by SignDeterministic before giving up. A nonce is rejected with a probability
+// of about 2**-128, so running out of candidates means the inputs can't be signed
+const SIGN_DETERMINISTIC_MAX_ATTEMPTS = 64
+
+// Message hashes and the r component of signatures are bounded by 2**251
+func ecdsaUpperBound() *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), 251)
+}
+
+func starkCurveOrder() *big.Int {
+	order, _ := new(big.Int).SetString(lambdaworks.STARK_CURVE_ORDER_HEX, 0)
+	return order
+}
+
+// Checks that 1 <= value < bound (or 0 <= value < bound when zero is allowed)
+func inRange(value *big.Int, allowZero bool, bound *big.Int) bool {
+	return (value.Sign() > 0 || (allowZero && value.Sign() == 0)) && value.Cmp(bound) < 0
+}
+
+// Returns -p
+func (p AffinePoint) Neg() AffinePoint {
+	if p.Infinity {
+		return p
+	}
+	return NewAffinePoint(p.X, lambdaworks.FeltZero().Sub(p.Y))
+}
+
+// Signs msgHash with privKey over the STARK curve using k as nonce, as done by cairo-lang's `sign`.
+// Fails with ErrInvalidSignatureInput if privKey isn't in [1, order) or msgHash is out of range, and with
+// ErrInvalidNonce if k is out of range or yields an invalid signature, in which case another k should be used
+func Sign(privKey lambdaworks.Felt, msgHash lambdaworks.Felt, k lambdaworks.Felt) (lambdaworks.Felt, lambdaworks.Felt, error) {
+	order := starkCurveOrder()
+	upperBound := ecdsaUpperBound()
+	if !inRange(privKey.ToBigInt(), false, order) {
+		return lambdaworks.Felt{}, lambdaworks.Felt{}, errors.Wrap(ErrInvalidSignatureInput, "private key is not in [1, order)")
+	}
+	z := msgHash.ToBigInt()
+	if !inRange(z, true, upperBound) {
+		return lambdaworks.Felt{}, lambdaworks.Felt{}, errors.Wrapf(ErrInvalidSignatureInput, "message hash %s is not below 2**251", msgHash.ToHexString())
+	}
+	nonce := k.ToBigInt()
+	if !inRange(nonce, false, order) {
+		return lambdaworks.Felt{}, lambdaworks.Felt{}, errors.Wrapf(ErrInvalidNonce, "k %s is not a valid nonce", k.ToHexString())
+	}
+
+	r := StarkCurveGenerator().ScalarMul(nonce).X.ToBigInt()
+	if !inRange(r, false, upperBound) {
+		return lambdaworks.Felt{}, lambdaworks.Felt{}, errors.Wrapf(ErrInvalidNonce, "k %s yields an r out of range", k.ToHexString())
+	}
+	// s = (z + r * privKey) / k (mod order)
+	s := new(big.Int).Mul(r, privKey.ToStarkCurveScalar())
+	s.Add(s, z).Mod(s, order)
+	if s.Sign() == 0 {
+		return lambdaworks.Felt{}, lambdaworks.Felt{}, errors.Wrapf(ErrInvalidNonce, "k %s yields a zero s", k.ToHexString())
+	}
+	s.Mul(s, new(big.Int).ModInverse(nonce, order)).Mod(s, order)
+	// Verify requires the inverse of s to be below 2**251 too
+	if !inRange(new(big.Int).ModInverse(s, order), false, upperBound) {
+		return lambdaworks.Felt{}, lambdaworks.Felt{}, errors.Wrapf(ErrInvalidNonce, "k %s yields an s out of range", k.ToHexString())
+	}
+	return lambdaworks.FeltFromBigInt(r), lambdaworks.FeltFromBigInt(s), nil
+}
+
+// Signs msgHash with privKey, deriving the nonce from both of them following RFC 6979 (with HMAC-SHA256 and the
+// curve's order as q) like cairo-lang's `generate_k_rfc6979`, so that signing the same message with the same key
+// always yields the same signature.
+// Fails with ErrInvalidSignatureInput if privKey isn't in [1, order) or msgHash is out of range, and with
+// ErrInvalidNonce if none of the first SIGN_DETERMINISTIC_MAX_ATTEMPTS nonces yields a valid signature
+func SignDeterministic(privKey lambdaworks.Felt, msgHash lambdaworks.Felt) (lambdaworks.Felt, lambdaworks.Felt, error) {
+	if !inRange(privKey.ToBigInt(), false, starkCurveOrder()) {
+		return lambdaworks.Felt{}, lambdaworks.Felt{}, errors.Wrap(ErrInvalidSignatureInput, "private key is not in [1, order)")
+	}
+	if !inRange(msgHash.ToBigInt(), true, ecdsaUpperBound()) {
+		return lambdaworks.Felt{}, lambdaworks.Felt{}, errors.Wrapf(ErrInvalidSignatureInput, "message hash %s is not below 2**251", msgHash.ToHexString())
+	}
+	nonces := newRfc6979Nonces(privKey.ToBigInt(), msgHash.ToBigInt())
+	for attempt := 0; attempt < SIGN_DETERMINISTIC_MAX_ATTEMPTS; attempt++ {
+		r, s, err := Sign(privKey, msgHash, lambdaworks.FeltFromBigInt(nonces.next()))
+		if err == nil {
+			return r, s, nil
+		}
+		if !errors.Is(err, ErrInvalidNonce) {
+			return lambdaworks.Felt{}, lambdaworks.Felt{}, err
+		}
+	}
+	return lambdaworks.Felt{}, lambdaworks.Felt{}, errors.Wrapf(ErrInvalidNonce, "no valid nonce found after %d attempts", SIGN_DETERMINISTIC_MAX_ATTEMPTS)
+}
+
+// Generator of the candidate nonces of RFC 6979, section 3.2
+type rfc6979Nonces struct {
+	order *big.Int
+	k     []byte
+	v     []byte
+	// Set once the first candidate was generated, the following ones require updating k and v
+	started bool
+}
+
+func newRfc6979Nonces(privKey *big.Int, msgHash *big.Int) *rfc6979Nonces {
+	order := starkCurveOrder()
+	g := &rfc6979Nonces{order: order, k: make([]byte, sha256.Size), v: make([]byte, sha256.Size)}
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+	// As in cairo-lang, the hash is taken as its minimal big endian bytes, padded with a nibble when it's one
+	// nibble short of a whole byte so that bitsToInt doesn't truncate its lowest bits
+	hash := new(big.Int).Set(msgHash)
+	if bitLen := hash.BitLen(); bitLen >= 248 && bitLen%8 >= 1 && bitLen%8 <= 4 {
+		hash.Lsh(hash, 4)
+	}
+	x := privKey.FillBytes(make([]byte, 32))
+	h := new(big.Int).Mod(g.bitsToInt(hash.Bytes()), order).FillBytes(make([]byte, 32))
+	for _, separator := range []byte{0x00, 0x01} {
+		g.k = g.hmac(g.k, g.v, []byte{separator}, x, h)
+		g.v = g.hmac(g.k, g.v)
+	}
+	return g
+}
+
+func (g *rfc6979Nonces) hmac(key []byte, data ...[]byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	for _, d := range data {
+		mac.Write(d)
+	}
+	return mac.Sum(nil)
+}
+
+// Interprets the leftmost bits of data (as many as the order has) as an integer
+func (g *rfc6979Nonces) bitsToInt(data []byte) *big.Int {
+	value := new(big.Int).SetBytes(data)
+	if excess := len(data)*8 - g.order.BitLen(); excess > 0 {
+		value.Rsh(value, uint(excess))
+	}
+	return value
+}
+
+// Returns the next candidate nonce in [1, order)
+func (g *rfc6979Nonces) next() *big.Int {
+	for {
+		if g.started {
+			g.k = g.hmac(g.k, g.v, []byte{0x00})
+			g.v = g.hmac(g.k, g.v)
+		}
+		g.started = true
+		// As the order has 252 bits, a single HMAC output is enough
+		g.v = g.hmac(g.k, g.v)
+		candidate := g.bitsToInt(g.v)
+		if inRange(candidate, false, g.order) {
+			return candidate
+		}
+	}
+}
+
+// Verifies the signature (r, s) of msgHash, where pubKey is the x coordinate of the signer's public key.
+// Returns an error wrapping ErrInvalidSignatureInput if the inputs are out of range or pubKey isn't on the curve
+func Verify(pubKey lambdaworks.Felt, msgHash lambdaworks.Felt, r lambdaworks.Felt, s lambdaworks.Felt) (bool, error) {
+	order := starkCurveOrder()
+	upperBound := ecdsaUpperBound()
+	z := msgHash.ToBigInt()
+	rValue := r.ToBigInt()
+	sValue := s.ToBigInt()
+	if !inRange(z, true, upperBound) {
+		return false, errors.Wrapf(ErrInvalidSignatureInput, "message hash %s is not below 2**251", msgHash.ToHexString())
+	}
+	if !inRange(rValue, false, upperBound) {
+		return false, errors.Wrapf(ErrInvalidSignatureInput, "r %s is out of range", r.ToHexString())
+	}
+	if !inRange(sValue, false, order) {
+		return false, errors.Wrapf(ErrInvalidSignatureInput, "s %s is out of range", s.ToHexString())
+	}
+	w := new(big.Int).ModInverse(sValue, order)
+	if w == nil || !inRange(w, false, upperBound) {
+		return false, errors.Wrapf(ErrInvalidSignatureInput, "s %s has no valid inverse", s.ToHexString())
+	}
+
+	// Only the x coordinate of the public key is known, so both of its possible points are checked
+	ySquared := pubKey.PowUint(3).Add(starkCurveAlpha().Mul(pubKey)).Add(starkCurveBeta())
+	y, ok := ySquared.Sqrt()
+	if !ok {
+		return false, errors.Wrapf(ErrInvalidSignatureInput, "public key %s is not on the curve", pubKey.ToHexString())
+	}
+	publicKey := NewAffinePoint(pubKey, y)
+
+	// The signature is valid if w * (z * G +- r * Q) has r as x coordinate
+	zG := StarkCurveGenerator().ScalarMul(z)
+	rQ := publicKey.ScalarMul(rValue)
+	for _, point := range []AffinePoint{zG.Add(rQ), zG.Add(rQ.Neg())} {
+		result := point.ScalarMul(w)
+		if !result.Infinity && result.X == r {
+			return true, nil
+		}
+	}
+	return false, nil
+}
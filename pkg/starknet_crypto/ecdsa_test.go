@@ -0,0 +1,116 @@
+package starknet_crypto_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	starknet_crypto "github.com/lambdaclass/cairo-vm.go/pkg/starknet_crypto"
+)
+
+func signTestMessage(t *testing.T) (lambdaworks.Felt, lambdaworks.Felt, lambdaworks.Felt, lambdaworks.Felt) {
+	privKey := lambdaworks.FeltFromHex("0x139fe4d6f02e666e86a6f58e65060f115cd3c185bd9e98bd829636931458f79")
+	msgHash := lambdaworks.FeltFromHex("0x6fea80189363a786037ed3e7ba546dad0ef7de49fccae0e31eb658b7dd4ea76")
+	k := lambdaworks.FeltFromHex("0x4daebba599f860daee8f6e100601d98873052e1c61530c630cc4375c6bd48e3")
+	r, s, err := starknet_crypto.Sign(privKey, msgHash, k)
+	if err != nil {
+		t.Errorf("Sign failed with error: %s", err)
+	}
+	pubKey := starknet_crypto.StarkCurveGenerator().ScalarMul(privKey.ToStarkCurveScalar()).X
+	return pubKey, msgHash, r, s
+}
+
+func TestSign(t *testing.T) {
+	_, _, r, s := signTestMessage(t)
+	expectedR := lambdaworks.FeltFromHex("0x34ffbd2cd71fce104a13e8003bbd4a17aee31371a6fe72eff0efcd195f19ff3")
+	expectedS := lambdaworks.FeltFromHex("0x42e2665aca21934bca93eb1d589734759e58d43ee609514f05d608019d2711b")
+	if r != expectedR || s != expectedS {
+		t.Errorf("Wrong signature. Expected (%v, %v), got (%v, %v)", expectedR, expectedS, r, s)
+	}
+}
+
+func TestVerifyValidSignature(t *testing.T) {
+	pubKey, msgHash, r, s := signTestMessage(t)
+	valid, err := starknet_crypto.Verify(pubKey, msgHash, r, s)
+	if err != nil || !valid {
+		t.Errorf("Verify should accept the signature, got %t, %v", valid, err)
+	}
+	// The signature is also accepted by the signature builtin's verifier
+	if !starknet_crypto.VerifySignature(pubKey, msgHash, r, s) {
+		t.Errorf("VerifySignature should accept the signature")
+	}
+}
+
+func TestVerifyTamperedSignature(t *testing.T) {
+	pubKey, msgHash, r, s := signTestMessage(t)
+	valid, err := starknet_crypto.Verify(pubKey, msgHash, r, s.Add(lambdaworks.FeltOne()))
+	if err != nil || valid {
+		t.Errorf("Verify should reject a tampered signature, got %t, %v", valid, err)
+	}
+	valid, err = starknet_crypto.Verify(pubKey, msgHash.Add(lambdaworks.FeltOne()), r, s)
+	if err != nil || valid {
+		t.Errorf("Verify should reject a signature of another message, got %t, %v", valid, err)
+	}
+}
+
+func TestVerifyOutOfRangeR(t *testing.T) {
+	pubKey, msgHash, _, s := signTestMessage(t)
+	_, err := starknet_crypto.Verify(pubKey, msgHash, lambdaworks.FeltZero(), s)
+	if !errors.Is(err, starknet_crypto.ErrInvalidSignatureInput) {
+		t.Errorf("Verify should fail with ErrInvalidSignatureInput, got %v", err)
+	}
+}
+
+func TestSignDeterministic(t *testing.T) {
+	privKey := lambdaworks.FeltFromHex("0x139fe4d6f02e666e86a6f58e65060f115cd3c185bd9e98bd829636931458f79")
+	msgHash := lambdaworks.FeltFromHex("0x6fea80189363a786037ed3e7ba546dad0ef7de49fccae0e31eb658b7dd4ea76")
+	r1, s1, err := starknet_crypto.SignDeterministic(privKey, msgHash)
+	if err != nil {
+		t.Errorf("SignDeterministic failed with error: %s", err)
+		return
+	}
+	r2, s2, err := starknet_crypto.SignDeterministic(privKey, msgHash)
+	if err != nil {
+		t.Errorf("SignDeterministic failed with error: %s", err)
+		return
+	}
+	if r1 != r2 || s1 != s2 {
+		t.Errorf("SignDeterministic should be deterministic, got (%v, %v) and (%v, %v)", r1, s1, r2, s2)
+	}
+	pubKey := starknet_crypto.StarkCurveGenerator().ScalarMul(privKey.ToStarkCurveScalar()).X
+	valid, err := starknet_crypto.Verify(pubKey, msgHash, r1, s1)
+	if err != nil || !valid {
+		t.Errorf("Verify should accept the deterministic signature, got %t, %v", valid, err)
+	}
+	// Another message uses another nonce
+	r3, _, err := starknet_crypto.SignDeterministic(privKey, msgHash.Add(lambdaworks.FeltOne()))
+	if err != nil || r3 == r1 {
+		t.Errorf("Signing another message should yield another r, got %v, %v", r3, err)
+	}
+}
+
+func TestSignDeterministicInvalidPrivateKey(t *testing.T) {
+	_, _, err := starknet_crypto.SignDeterministic(lambdaworks.FeltZero(), lambdaworks.FeltZero())
+	if !errors.Is(err, starknet_crypto.ErrInvalidSignatureInput) {
+		t.Errorf("SignDeterministic should fail with ErrInvalidSignatureInput, got %v", err)
+	}
+}
+
+func TestSignZeroNonce(t *testing.T) {
+	privKey := lambdaworks.FeltFromHex("0x139fe4d6f02e666e86a6f58e65060f115cd3c185bd9e98bd829636931458f79")
+	_, _, err := starknet_crypto.Sign(privKey, lambdaworks.FeltOne(), lambdaworks.FeltZero())
+	if !errors.Is(err, starknet_crypto.ErrInvalidNonce) {
+		t.Errorf("Sign should fail with ErrInvalidNonce, got %v", err)
+	}
+}
+
+func TestSignInvalidPrivateKey(t *testing.T) {
+	k := lambdaworks.FeltFromHex("0x4daebba599f860daee8f6e100601d98873052e1c61530c630cc4375c6bd48e3")
+	order := lambdaworks.FeltFromHex(lambdaworks.STARK_CURVE_ORDER_HEX)
+	for _, privKey := range []lambdaworks.Felt{lambdaworks.FeltZero(), order, order.Add(lambdaworks.FeltOne())} {
+		_, _, err := starknet_crypto.Sign(privKey, lambdaworks.FeltOne(), k)
+		if !errors.Is(err, starknet_crypto.ErrInvalidSignatureInput) {
+			t.Errorf("Sign with private key %s should fail with ErrInvalidSignatureInput, got %v", privKey.ToHexString(), err)
+		}
+	}
+}
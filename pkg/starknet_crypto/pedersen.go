@@ -0,0 +1,44 @@
+package starknet_crypto
+
+import (
+	"math/big"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+)
+
+// Amount of low bits of each input multiplied by the first of its Pedersen points, the remaining 4 bits are
+// multiplied by the second one
+const PEDERSEN_LOW_PART_BITS = 248
+
+// Points of the Pedersen hash as defined by cairo-lang: the shift point, followed by the points of the low and high
+// parts of the first input and the ones of the second input
+var pedersenPointsHex = [5][2]string{
+	{"0x49ee3eba8c1600700ee1b87eb599f16716b0b1022947733551fde4050ca6804", "0x3ca0cfe4b3bc6ddf346d49d06ea0ed34e621062c0e056c1d0405d266e10268a"},
+	{"0x234287dcbaffe7f969c748655fca9e58fa8120b6d56eb0c1080d17957ebe47b", "0x3b056f100f96fb21e889527d41f4e39940135dd7a6c94cc6ed0268ee89e5615"},
+	{"0x4fa56f376c83db33f9dab2656558f3399099ec1de5e3018b7a6932dba8aa378", "0x3fa0984c931c9e38113e0c0e47e4401562761f92a7a23b45168f4e80ff5b54d"},
+	{"0x4ba4cc166be8dec764910f75b45f74b40c690c74709e90f3aa372f0bd2d6997", "0x40301cf5c1751f4b971e46c4ede85fcac5c59a5ce5ae7c48151f27b24b219c"},
+	{"0x54302dcb0e6cc1c6e44cca8f61a63bb2ca65048d53fb325d36ff12c49a58202", "0x1b77b3e37d13504b348046268d8ae25ce98ad783c25561a879dcc77e99c2426"},
+}
+
+func pedersenPoints() [5]AffinePoint {
+	var points [5]AffinePoint
+	for i, point := range pedersenPointsHex {
+		points[i] = NewAffinePoint(lambdaworks.FeltFromHex(point[0]), lambdaworks.FeltFromHex(point[1]))
+	}
+	return points
+}
+
+// Computes the Pedersen hash of f1 and f2 using the curve arithmetic of this package instead of the
+// starknet_crypto library, as the x coordinate of shift + low(f1) * P1 + high(f1) * P2 + low(f2) * P3 + high(f2) * P4
+func PedersenHashGo(f1 lambdaworks.Felt, f2 lambdaworks.Felt) lambdaworks.Felt {
+	points := pedersenPoints()
+	lowMask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), PEDERSEN_LOW_PART_BITS), big.NewInt(1))
+	result := points[0]
+	for i, felt := range []lambdaworks.Felt{f1, f2} {
+		value := felt.ToBigInt()
+		low := new(big.Int).And(value, lowMask)
+		high := new(big.Int).Rsh(value, PEDERSEN_LOW_PART_BITS)
+		result = result.Add(points[1+2*i].ScalarMul(low)).Add(points[2+2*i].ScalarMul(high))
+	}
+	return result.X
+}
@@ -0,0 +1,25 @@
+//go:build !purego
+
+package starknet_crypto
+
+/*
+#include "lib/starknet_crypto.h"
+*/
+import "C"
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+)
+
+// Computes the Pedersen hash of f1 and f2 using the starknet_crypto library.
+// Building with the purego tag uses PedersenHashGo instead
+func PedersenHash(f1 lambdaworks.Felt, f2 lambdaworks.Felt) lambdaworks.Felt {
+	felt_1 := toC(f1)
+	felt_2 := toC(f2)
+	var result C.felt_t
+
+	C.pedersen_hash(&felt_1[0], &felt_2[0], &result[0])
+
+	hash := fromC(result)
+
+	return hash
+}
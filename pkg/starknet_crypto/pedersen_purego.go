@@ -0,0 +1,12 @@
+//go:build purego
+
+package starknet_crypto
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+)
+
+// Computes the Pedersen hash of f1 and f2 with PedersenHashGo, as the package was built with the purego tag
+func PedersenHash(f1 lambdaworks.Felt, f2 lambdaworks.Felt) lambdaworks.Felt {
+	return PedersenHashGo(f1, f2)
+}
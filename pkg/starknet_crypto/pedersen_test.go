@@ -0,0 +1,31 @@
+package starknet_crypto_test
+
+import (
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	starknet_crypto "github.com/lambdaclass/cairo-vm.go/pkg/starknet_crypto"
+)
+
+func TestPedersenHashGoKnownVectors(t *testing.T) {
+	vectors := []struct{ a, b, hash string }{
+		{"0x20", "0x48", "0x73b3ec210cccbb970f80c6826fb1c40ae9f487617696234ff147451405c339f"},
+		{"0x3d937c035c878245caf64531a5756109c53068da139362728feb561405371cb", "0x208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a", "0x30e480bed5fe53fa909cc0f8c4d99b8f9f2c016be4c41e13a4848797979c662"},
+		// Inputs with their 4 high bits set
+		{"0x800000000000011000000000000000000000000000000000000000000000000", "0x800000000000011000000000000000000000000000000000000000000000000", "0x7258fccaf3371fad51b117471d9d888a1786c5694c3e6099160477b593a576e"},
+		// The hash of zeros is the shift point
+		{"0x0", "0x0", "0x49ee3eba8c1600700ee1b87eb599f16716b0b1022947733551fde4050ca6804"},
+	}
+	for _, vector := range vectors {
+		a := lambdaworks.FeltFromHex(vector.a)
+		b := lambdaworks.FeltFromHex(vector.b)
+		expected := lambdaworks.FeltFromHex(vector.hash)
+		hash := starknet_crypto.PedersenHashGo(a, b)
+		if hash != expected {
+			t.Errorf("Wrong hash of (%s, %s). Expected %s, got %s", vector.a, vector.b, vector.hash, hash.ToHexString())
+		}
+		if ffiHash := starknet_crypto.PedersenHash(a, b); ffiHash != hash {
+			t.Errorf("PedersenHashGo and PedersenHash differ for (%s, %s): %s and %s", vector.a, vector.b, hash.ToHexString(), ffiHash.ToHexString())
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package starknet_crypto
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+)
+
+// Parameters of the Hades permutation used by Starknet's Poseidon hash over a state of 3 felts
+const POSEIDON_FULL_ROUNDS = 8
+const POSEIDON_PARTIAL_ROUNDS = 83
+
+var poseidonRoundKeysOnce sync.Once
+var poseidonRoundKeys [POSEIDON_FULL_ROUNDS + POSEIDON_PARTIAL_ROUNDS][3]lambdaworks.Felt
+
+// Returns the round keys of the permutation. As in cairo-lang, the i-th key is sha256("Hades<i>") reduced
+// modulo the cairo prime, and the keys are taken three at a time for each round
+func poseidonKeys() *[POSEIDON_FULL_ROUNDS + POSEIDON_PARTIAL_ROUNDS][3]lambdaworks.Felt {
+	poseidonRoundKeysOnce.Do(func() {
+		for round := range poseidonRoundKeys {
+			for i := range poseidonRoundKeys[round] {
+				digest := sha256.Sum256([]byte(fmt.Sprintf("Hades%d", 3*round+i)))
+				poseidonRoundKeys[round][i] = lambdaworks.FeltFromBigInt(new(big.Int).SetBytes(digest[:]))
+			}
+		}
+	})
+	return &poseidonRoundKeys
+}
+
+// Applies the Poseidon permutation to the state using the field arithmetic of lambdaworks instead of the
+// starknet_crypto library. Half of the full rounds run before the partial rounds and half after them,
+// partial rounds only cube the last element of the state
+func PoseidonPermuteGo(poseidon_state *[3]lambdaworks.Felt) {
+	state := *poseidon_state
+	keys := poseidonKeys()
+	for round := range keys {
+		for i := range state {
+			state[i] = state[i].Add(keys[round][i])
+		}
+		if round < POSEIDON_FULL_ROUNDS/2 || round >= POSEIDON_FULL_ROUNDS/2+POSEIDON_PARTIAL_ROUNDS {
+			for i := range state {
+				state[i] = state[i].PowUint(3)
+			}
+		} else {
+			state[2] = state[2].PowUint(3)
+		}
+		// MDS matrix [[3, 1, 1], [1, -1, 1], [1, 1, -2]]
+		sum := state[0].Add(state[1]).Add(state[2])
+		state = [3]lambdaworks.Felt{
+			sum.Add(state[0]).Add(state[0]),
+			sum.Sub(state[1]).Sub(state[1]),
+			sum.Sub(state[2]).Sub(state[2]).Sub(state[2]),
+		}
+	}
+	*poseidon_state = state
+}
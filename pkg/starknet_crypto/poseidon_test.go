@@ -0,0 +1,37 @@
+package starknet_crypto_test
+
+import (
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	starknet_crypto "github.com/lambdaclass/cairo-vm.go/pkg/starknet_crypto"
+)
+
+func TestPoseidonPermuteGoKnownVectors(t *testing.T) {
+	vectors := []struct{ input, output [3]string }{
+		{
+			[3]string{"0x3", "0x0", "0x2"},
+			[3]string{"0x268c44203f1c763bca21beb5aec78b9063cdcdd0fdf6b598bb8e1e8f2b6253f", "0x2b85c9f686f5d3036db55b2ca58a763a3065bc1bc8efbe0e70f3a7171f6cad3", "0x61df3789eef0e1ee0dbe010582a00dd099191e6395dfb976e7be3be2fa9d54b"},
+		},
+		{
+			[3]string{"0x268c44203f1c763bca21beb5aec78b9063cdcdd0fdf6b598bb8e1e8f2b6253f", "0x2b85c9f686f5d3036db55b2ca58a763a3065bc1bc8efbe0e70f3a7171f6cad3", "0x61df3789eef0e1ee0dbe010582a00dd099191e6395dfb976e7be3be2fa9d54b"},
+			[3]string{"0x4ec565b1b01606b5222602b20f8ddc4a8a7c75b559b852ab183a0daf5930b5c", "0x4d3c32c3c7cd39b6444db42e2437eeda12e459d28ce49a0f761a23d64c29e4c", "0x749d4d0ddf41548e039f183b745a08b80fad54e9ac389021148350bdda70a92"},
+		},
+	}
+	for _, vector := range vectors {
+		var state, expected [3]lambdaworks.Felt
+		for i := range state {
+			state[i] = lambdaworks.FeltFromHex(vector.input[i])
+			expected[i] = lambdaworks.FeltFromHex(vector.output[i])
+		}
+		ffiState := state
+		starknet_crypto.PoseidonPermuteGo(&state)
+		if state != expected {
+			t.Errorf("Wrong permutation of %v. Expected %v, got %v", vector.input, expected, state)
+		}
+		starknet_crypto.PoseidonPermuteComp(&ffiState)
+		if ffiState != state {
+			t.Errorf("PoseidonPermuteGo and PoseidonPermuteComp differ for %v: %v and %v", vector.input, state, ffiState)
+		}
+	}
+}
@@ -1,71 +1,31 @@
 package starknet_crypto
 
-/*
-#cgo LDFLAGS: pkg/starknet_crypto/lib/libstarknet_crypto.a -ldl
-#include "lib/starknet_crypto.h"
-#include <stdlib.h>
-*/
-import "C"
 import (
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 )
 
-// Converts a Go Felt to a C felt_t.
-func toC(f lambdaworks.Felt) C.felt_t {
-	var result C.felt_t
-	for i, byte := range f.ToBeBytes() {
-		result[i] = C.byte_t(byte)
+// Computes the Poseidon hash of an array of felts using the sponge construction (rate 2, capacity 1).
+// The input is padded with a one followed by a zero if needed so that its length is even
+func PoseidonHashMany(felts []lambdaworks.Felt) lambdaworks.Felt {
+	input := append(append(make([]lambdaworks.Felt, 0, len(felts)+2), felts...), lambdaworks.FeltOne())
+	if len(input)%2 != 0 {
+		input = append(input, lambdaworks.FeltZero())
 	}
-	return result
-}
-
-// Converts a C felt_t to a Go Felt.
-func fromC(result C.felt_t) lambdaworks.Felt {
-	var bytes [32]uint8
-	for i, byte := range result {
-		bytes[i] = uint8(byte)
+	state := [3]lambdaworks.Felt{lambdaworks.FeltZero(), lambdaworks.FeltZero(), lambdaworks.FeltZero()}
+	for i := 0; i < len(input); i += 2 {
+		state[0] = state[0].Add(input[i])
+		state[1] = state[1].Add(input[i+1])
+		PoseidonPermuteComp(&state)
 	}
-	return lambdaworks.FeltFromBeBytes(&bytes)
+	return state[0]
 }
 
-func PoseidonPermuteComp(poseidon_state *[3]lambdaworks.Felt) {
-	state := *poseidon_state
-	// Convert args to c representation
-	first_state_felt := toC(state[0])
-	second_state_felt := toC(state[1])
-	third_state_felt := toC(state[2])
-
-	// Compute hash using starknet_crypto C wrapper
-	C.poseidon_permute(&first_state_felt[0], &second_state_felt[0], &third_state_felt[0])
-	// Convert result to Go representation
-	var new_poseidon_state = [3]lambdaworks.Felt{
-		fromC(first_state_felt),
-		fromC(second_state_felt),
-		fromC(third_state_felt),
+// Computes the cumulative Pedersen hash of an array of felts, as used by Starknet:
+// H(...H(H(0, a0), a1)..., n), where n is the length of the array
+func PedersenHashArray(felts []lambdaworks.Felt) lambdaworks.Felt {
+	hash := lambdaworks.FeltZero()
+	for _, felt := range felts {
+		hash = PedersenHash(hash, felt)
 	}
-	// Update poseidon state
-	*poseidon_state = new_poseidon_state
-}
-
-func PedersenHash(f1 lambdaworks.Felt, f2 lambdaworks.Felt) lambdaworks.Felt {
-	felt_1 := toC(f1)
-	felt_2 := toC(f2)
-	var result C.felt_t
-
-	C.pedersen_hash(&felt_1[0], &felt_2[0], &result[0])
-
-	hash := fromC(result)
-
-	return hash
-}
-
-func VerifySignature(public_key lambdaworks.Felt, message lambdaworks.Felt, r lambdaworks.Felt, s lambdaworks.Felt) bool {
-	public_key_for_c := toC(public_key)
-	message_for_c := toC(message)
-	r_for_c := toC(r)
-	s_for_c := toC(s)
-
-	c_verify_status := C.verify_signature(&public_key_for_c[0], &message_for_c[0], &r_for_c[0], &s_for_c[0])
-
-	return bool(c_verify_status)
+	return PedersenHash(hash, lambdaworks.FeltFromUint64(uint64(len(felts))))
 }
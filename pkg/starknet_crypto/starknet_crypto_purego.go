@@ -0,0 +1,19 @@
+//go:build purego
+
+package starknet_crypto
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+)
+
+// Applies the Poseidon permutation to the state with PoseidonPermuteGo, as the package was built with the purego tag
+func PoseidonPermuteComp(poseidon_state *[3]lambdaworks.Felt) {
+	PoseidonPermuteGo(poseidon_state)
+}
+
+// Verifies an ECDSA signature with Verify, as the package was built with the purego tag.
+// Inputs rejected by Verify are reported as an invalid signature
+func VerifySignature(public_key lambdaworks.Felt, message lambdaworks.Felt, r lambdaworks.Felt, s lambdaworks.Felt) bool {
+	valid, err := Verify(public_key, message, r, s)
+	return err == nil && valid
+}
@@ -1,6 +1,7 @@
 package starknet_crypto_test
 
 import (
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -48,6 +49,40 @@ func TestPoseidonPermuteCompB(t *testing.T) {
 	}
 }
 
+func TestPoseidonHashManyThreeElements(t *testing.T) {
+	felts := []lambdaworks.Felt{lambdaworks.FeltFromUint64(1), lambdaworks.FeltFromUint64(2), lambdaworks.FeltFromUint64(3)}
+
+	hash := starknet_crypto.PoseidonHashMany(felts)
+
+	// poseidon_hash_many([1, 2, 3]) from cairo-lang
+	expected := lambdaworks.FeltFromHex("0x2f0d8840bcf3bc629598d8a6cc80cb7c0d9e52d93dab244bbf9cd0dca0ad082")
+	if hash != expected {
+		t.Errorf("Wrong hash. Expected %v, got %v", expected, hash)
+	}
+}
+
+func TestPoseidonHashManyEvenLength(t *testing.T) {
+	felts := []lambdaworks.Felt{lambdaworks.FeltFromUint64(1), lambdaworks.FeltFromUint64(2)}
+
+	hash := starknet_crypto.PoseidonHashMany(felts)
+
+	// poseidon_hash_many([1, 2]) from cairo-lang
+	expected := lambdaworks.FeltFromHex("0x371cb6995ea5e7effcd2e174de264b5b407027a75a231a70c2c8d196107f0e7")
+	if hash != expected {
+		t.Errorf("Wrong hash. Expected %v, got %v", expected, hash)
+	}
+}
+
+func TestPoseidonHashManyEmpty(t *testing.T) {
+	hash := starknet_crypto.PoseidonHashMany([]lambdaworks.Felt{})
+
+	// poseidon_hash_many([]) from cairo-lang
+	expected := lambdaworks.FeltFromHex("0x2272be0f580fd156823304800919530eaa97430e972d7213ee13f4fbf7a5dbc")
+	if hash != expected {
+		t.Errorf("Wrong hash. Expected %v, got %v", expected, hash)
+	}
+}
+
 func TestPersenHash(t *testing.T) {
 	// Set initial state values
 	f1 := lambdaworks.FeltFromHex("0x20")
@@ -61,6 +96,28 @@ func TestPersenHash(t *testing.T) {
 	}
 }
 
+func TestPedersenHashArray(t *testing.T) {
+	felts := []lambdaworks.Felt{lambdaworks.FeltFromUint64(1), lambdaworks.FeltFromUint64(2)}
+
+	hash := starknet_crypto.PedersenHashArray(felts)
+
+	// compute_hash_on_elements([1, 2]) from cairo-lang
+	expected := lambdaworks.FeltFromHex("0x501a3a8e6cd4f5241c639c74052aaa34557aafa84dd4ba983d6443c590ab7df")
+	if hash != expected {
+		t.Errorf("Wrong hash. Expected %v, got %v", expected, hash)
+	}
+}
+
+func TestPedersenHashArrayEmpty(t *testing.T) {
+	hash := starknet_crypto.PedersenHashArray([]lambdaworks.Felt{})
+
+	// compute_hash_on_elements([]) is the hash of (0, 0), the x coordinate of the shift point
+	expected := lambdaworks.FeltFromHex("0x49ee3eba8c1600700ee1b87eb599f16716b0b1022947733551fde4050ca6804")
+	if hash != expected {
+		t.Errorf("Wrong hash. Expected %v, got %v", expected, hash)
+	}
+}
+
 func TestVerifySignatureShouldFail(t *testing.T) {
 	signature := lambdaworks.FeltFromHex("0x1")
 	msg_hash := lambdaworks.FeltFromHex("0x1")
@@ -95,3 +152,17 @@ func TestVerifyGoodSignature(t *testing.T) {
 		t.Errorf("Didn't verify a good signature")
 	}
 }
+
+func TestPedersenHashStarkCurveFieldRoundTrip(t *testing.T) {
+	a, _ := new(big.Int).SetString("0x3d937c035c878245caf64531a5756109c53068da139362728feb561405371cb", 0)
+	b, _ := new(big.Int).SetString("0x208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a", 0)
+	hash := starknet_crypto.PedersenHash(lambdaworks.FeltFromStarkCurveField(a), lambdaworks.FeltFromStarkCurveField(b))
+	roundTrip := lambdaworks.FeltFromStarkCurveField(hash.ToBigInt())
+	if roundTrip != hash {
+		t.Errorf("Wrong round trip of the pedersen hash. Expected %v, got %v", hash, roundTrip)
+	}
+	expected := lambdaworks.FeltFromHex("0x30e480bed5fe53fa909cc0f8c4d99b8f9f2c016be4c41e13a4848797979c662")
+	if hash != expected {
+		t.Errorf("Wrong pedersen hash. Expected %v, got %v", expected, hash)
+	}
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/pkg/errors"
+)
+
+// Maximum amount of felts FeltRange can generate
+var FeltRangeMaxLength uint64 = 1 << 20
+
+var ErrDescendingFeltRange = errors.New("Felt range start is greater than its end")
+var ErrFeltRangeTooLarge = errors.New("Felt range exceeds the maximum length")
+
+// Returns the felts from start to end, both included.
+// Fails if start is greater than end, or if the range has more than FeltRangeMaxLength elements
+func FeltRange(start lambdaworks.Felt, end lambdaworks.Felt) ([]lambdaworks.Felt, error) {
+	if start.Cmp(end) > 0 {
+		return nil, errors.Wrapf(ErrDescendingFeltRange, "start: %s, end: %s", start.ToHexString(), end.ToHexString())
+	}
+	distance, err := end.Sub(start).ToU64()
+	if err != nil || distance >= FeltRangeMaxLength {
+		return nil, errors.Wrapf(ErrFeltRangeTooLarge, "start: %s, end: %s, max length: %d", start.ToHexString(), end.ToHexString(), FeltRangeMaxLength)
+	}
+	felts := make([]lambdaworks.Felt, 0, distance+1)
+	for felt, i := start, uint64(0); i <= distance; felt, i = felt.Add(lambdaworks.FeltOne()), i+1 {
+		felts = append(felts, felt)
+	}
+	return felts, nil
+}
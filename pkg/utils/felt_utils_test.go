@@ -0,0 +1,47 @@
+package utils_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/utils"
+)
+
+func TestFeltRangeAscending(t *testing.T) {
+	felts, err := utils.FeltRange(lambdaworks.FeltFromUint64(3), lambdaworks.FeltFromUint64(6))
+	if err != nil {
+		t.Errorf("FeltRange failed with error: %s", err)
+	}
+	expected := []lambdaworks.Felt{
+		lambdaworks.FeltFromUint64(3),
+		lambdaworks.FeltFromUint64(4),
+		lambdaworks.FeltFromUint64(5),
+		lambdaworks.FeltFromUint64(6),
+	}
+	if !reflect.DeepEqual(felts, expected) {
+		t.Errorf("Wrong felt range. Expected %v, got %v", expected, felts)
+	}
+}
+
+func TestFeltRangeSingleElement(t *testing.T) {
+	felts, err := utils.FeltRange(lambdaworks.FeltFromUint64(3), lambdaworks.FeltFromUint64(3))
+	if err != nil || len(felts) != 1 || felts[0] != lambdaworks.FeltFromUint64(3) {
+		t.Errorf("Expected a single element range, got %v (error: %v)", felts, err)
+	}
+}
+
+func TestFeltRangeDescending(t *testing.T) {
+	_, err := utils.FeltRange(lambdaworks.FeltFromUint64(6), lambdaworks.FeltFromUint64(3))
+	if !errors.Is(err, utils.ErrDescendingFeltRange) {
+		t.Errorf("Expected ErrDescendingFeltRange, got %v", err)
+	}
+}
+
+func TestFeltRangeTooLarge(t *testing.T) {
+	_, err := utils.FeltRange(lambdaworks.FeltZero(), lambdaworks.FeltFromUint64(utils.FeltRangeMaxLength))
+	if !errors.Is(err, utils.ErrFeltRangeTooLarge) {
+		t.Errorf("Expected ErrFeltRangeTooLarge, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"math"
+	"math/big"
 
 	"github.com/pkg/errors"
 )
@@ -46,3 +47,17 @@ func MaxInt(x int, y int) int {
 func DivCeil(x uint, y uint) uint {
 	return 1 + (x-1)/y
 }
+
+// Computes a * b^-1 mod n, the result is a nonnegative integer x < n such that (b * x) % n == a % n.
+// Fails if b is not invertible modulo n.
+func DivMod(a *big.Int, b *big.Int, n *big.Int) (*big.Int, error) {
+	if n.Sign() != 1 {
+		return nil, errors.Errorf("Expected modulus %s to be positive", n)
+	}
+	bInv := new(big.Int).ModInverse(b, n)
+	if bInv == nil {
+		return nil, errors.Errorf("%s is not invertible modulo %s", b, n)
+	}
+	res := new(big.Int).Mul(a, bInv)
+	return res.Mod(res, n), nil
+}
@@ -0,0 +1,69 @@
+package utils_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/utils"
+)
+
+func TestDivModSmallValues(t *testing.T) {
+	cases := [][4]int64{
+		// a, b, n, expected
+		{2, 3, 5, 4},
+		{1, 2, 7, 4},
+		{-1, 3, 7, 2},
+		{10, 1, 7, 3},
+	}
+	for _, c := range cases {
+		res, err := utils.DivMod(big.NewInt(c[0]), big.NewInt(c[1]), big.NewInt(c[2]))
+		if err != nil {
+			t.Errorf("DivMod(%d, %d, %d) failed with error: %s", c[0], c[1], c[2], err)
+			continue
+		}
+		if res.Cmp(big.NewInt(c[3])) != 0 {
+			t.Errorf("DivMod(%d, %d, %d): expected %d, got %s", c[0], c[1], c[2], c[3], res)
+		}
+	}
+}
+
+func TestDivModCairoPrime(t *testing.T) {
+	a, _ := new(big.Int).SetString("11260647941622813594563746375280766662237311019551239924981511729608487775604310196863705127454617186486639011517352066501847110680463498585797912894788", 10)
+	b, _ := new(big.Int).SetString("4020711254448367604954374443741161860304516084891705811279711044808359405970", 10)
+	prime, _ := new(big.Int).SetString("800000000000011000000000000000000000000000000000000000000000001", 16)
+	expected, _ := new(big.Int).SetString("2904750555256547440469454488220756360634457312540595732507835416669695939476", 10)
+	aCopy := new(big.Int).Set(a)
+
+	res, err := utils.DivMod(a, b, prime)
+	if err != nil {
+		t.Errorf("DivMod failed with error: %s", err)
+	}
+	if res.Cmp(expected) != 0 {
+		t.Errorf("Expected result: %s to be equal to %s", res, expected)
+	}
+	if a.Cmp(aCopy) != 0 {
+		t.Errorf("DivMod should not modify its arguments")
+	}
+}
+
+func TestDivModNotInvertible(t *testing.T) {
+	_, err := utils.DivMod(big.NewInt(1), big.NewInt(2), big.NewInt(4))
+	if err == nil {
+		t.Errorf("DivMod should have failed as 2 is not invertible modulo 4")
+	}
+}
+
+func TestDivModZeroDivisor(t *testing.T) {
+	_, err := utils.DivMod(big.NewInt(1), big.NewInt(0), big.NewInt(7))
+	if err == nil {
+		t.Errorf("DivMod should have failed as 0 is not invertible modulo 7")
+	}
+}
+
+func TestDivModDivisorEqualToModulus(t *testing.T) {
+	prime, _ := new(big.Int).SetString("800000000000011000000000000000000000000000000000000000000000001", 16)
+	_, err := utils.DivMod(big.NewInt(1), prime, prime)
+	if err == nil {
+		t.Errorf("DivMod should have failed as the modulus is not invertible modulo itself")
+	}
+}
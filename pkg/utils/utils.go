@@ -4,13 +4,15 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Returns true if the elements of subsequence appear in sequence in the same order, not necessarily contiguously.
+// Each element is searched after the position where the previous one was found
 func IsSubsequence[T comparable](subsequence []T, sequence []T) bool {
 	startSeqIdx := 0
 	for _, subElem := range subsequence {
 		found := false
 		for idx, elem := range sequence[startSeqIdx:] {
 			if subElem == elem {
-				startSeqIdx = idx + 1
+				startSeqIdx += idx + 1
 				found = true
 				break
 			}
@@ -53,3 +53,14 @@ func TestIsSubsequenceReturnsFalse(t *testing.T) {
 		t.Errorf("The result of IsSubsequence should be false")
 	}
 }
+
+func TestIsSubsequenceDoesNotMatchEarlierElements(t *testing.T) {
+	sequence := []string{"a", "b", "c", "d", "e"}
+	// "b" comes before "c" and "d" in the sequence
+	if utils.IsSubsequence([]string{"c", "d", "b"}, sequence) {
+		t.Errorf("IsSubsequence should be false when an element only appears before the previous ones")
+	}
+	if !utils.IsSubsequence([]string{"b", "d", "e"}, sequence) {
+		t.Errorf("IsSubsequence should be true for elements in order with gaps")
+	}
+}
@@ -1,10 +1,7 @@
 package cairo_run
 
 import (
-	"encoding/binary"
-	"fmt"
 	"io"
-	"sort"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/hints"
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
@@ -24,6 +21,8 @@ type CairoRunConfig struct {
 	DisableTracePadding bool
 	ProofMode           bool
 	Layout              string
+	// Allows running programs that use builtins missing from the layout, ignored in proof mode
+	AllowMissingBuiltins bool
 }
 
 func CairoRunError(err error) error {
@@ -35,7 +34,10 @@ func CairoRun(programPath string, cairoRunConfig CairoRunConfig) (*runners.Cairo
 	if err != nil {
 		return nil, CairoRunError(err)
 	}
-	programJson := vm.DeserializeProgramJson(compiledProgram)
+	programJson, err := vm.DeserializeProgramJson(compiledProgram)
+	if err != nil {
+		return nil, CairoRunError(err)
+	}
 
 	layout := cairoRunConfig.Layout
 	proofMode := cairoRunConfig.ProofMode
@@ -44,6 +46,7 @@ func CairoRun(programPath string, cairoRunConfig CairoRunConfig) (*runners.Cairo
 	if err != nil {
 		return nil, err
 	}
+	cairoRunner.AllowMissingBuiltins = cairoRunConfig.AllowMissingBuiltins
 	end, err := cairoRunner.Initialize()
 	if err != nil {
 		return nil, err
@@ -76,46 +79,7 @@ func CairoRun(programPath string, cairoRunConfig CairoRunConfig) (*runners.Cairo
 // Bincode encodes to little endian by default and each trace entry is composed of
 // 3 usize values that are padded to always reach 64 bit size.
 func WriteEncodedTrace(relocatedTrace []vm.RelocatedTraceEntry, dest io.Writer) error {
-	for i, entry := range relocatedTrace {
-		ap_buffer := make([]byte, 8)
-		ap, err := entry.Ap.ToU64()
-		if err != nil {
-			return err
-		}
-		binary.LittleEndian.PutUint64(ap_buffer, ap)
-		_, err = dest.Write(ap_buffer)
-		if err != nil {
-			return encodeTraceError(i, err)
-		}
-
-		fp_buffer := make([]byte, 8)
-		fp, err := entry.Fp.ToU64()
-		if err != nil {
-			return err
-		}
-		binary.LittleEndian.PutUint64(fp_buffer, fp)
-		_, err = dest.Write(fp_buffer)
-		if err != nil {
-			return encodeTraceError(i, err)
-		}
-
-		pc_buffer := make([]byte, 8)
-		pc, err := entry.Pc.ToU64()
-		if err != nil {
-			return err
-		}
-		binary.LittleEndian.PutUint64(pc_buffer, pc)
-		_, err = dest.Write(pc_buffer)
-		if err != nil {
-			return encodeTraceError(i, err)
-		}
-	}
-
-	return nil
-}
-
-func encodeTraceError(i int, err error) error {
-	return errors.New(fmt.Sprintf("Failed to encode trace at position %d, serialize error: %s", i, err))
+	return vm.WriteEncodedTrace(relocatedTrace, dest)
 }
 
 // Writes a binary representation of the relocated memory.
@@ -124,38 +88,5 @@ func encodeTraceError(i int, err error) error {
 // * address -> 8-byte encoded
 // * value -> 32-byte encoded
 func WriteEncodedMemory(relocatedMemory map[uint]lambdaworks.Felt, dest io.Writer) error {
-	// create a slice to store keys of the relocatedMemory map
-	keysMap := make([]uint, 0, len(relocatedMemory))
-	for k := range relocatedMemory {
-		keysMap = append(keysMap, k)
-	}
-
-	// sort the keys
-	sort.Slice(keysMap, func(i, j int) bool { return keysMap[i] < keysMap[j] })
-
-	// iterate over the `relocatedMemory` map in sorted key order
-	for _, k := range keysMap {
-
-		// write the key
-		keyArray := make([]byte, 8)
-		binary.LittleEndian.PutUint64(keyArray, uint64(k))
-		_, err := dest.Write(keyArray)
-		if err != nil {
-			return encodeMemoryError(k, err)
-		}
-
-		// write the value
-		valueArray := relocatedMemory[k].ToLeBytes()
-
-		_, err = dest.Write(valueArray[:])
-		if err != nil {
-			return encodeMemoryError(k, err)
-		}
-	}
-
-	return nil
-}
-
-func encodeMemoryError(i uint, err error) error {
-	return fmt.Errorf("Failed to encode trace at position %d, serialize error: %s", i, err)
+	return vm.WriteEncodedMemory(relocatedMemory, dest)
 }
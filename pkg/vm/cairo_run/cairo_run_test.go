@@ -2,8 +2,11 @@ package cairo_run_test
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/lambdaclass/cairo-vm.go/pkg/runners"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/cairo_run"
 )
 
@@ -23,6 +26,26 @@ func TestFibonacciProofMode(t *testing.T) {
 	}
 }
 
+func TestFibonacciProofModeWriteProverArtifacts(t *testing.T) {
+	cairoRunConfig := cairo_run.CairoRunConfig{DisableTracePadding: false, Layout: "all_cairo", ProofMode: true}
+	runner, err := cairo_run.CairoRun("../../../cairo_programs/proof_programs/fibonacci.json", cairoRunConfig)
+	if err != nil {
+		t.Errorf("Program execution failed with error: %s", err)
+		return
+	}
+	dir := t.TempDir()
+	err = runner.WriteProverArtifacts(dir, &runner.Vm)
+	if err != nil {
+		t.Errorf("WriteProverArtifacts failed with error: %s", err)
+	}
+	for _, name := range []string{runners.TRACE_FILE_NAME, runners.MEMORY_FILE_NAME, runners.AIR_PUBLIC_INPUT_FILE_NAME, runners.AIR_PRIVATE_INPUT_FILE_NAME} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil || info.Size() == 0 {
+			t.Errorf("Expected %s to be written and non-empty", name)
+		}
+	}
+}
+
 func TestFactorial(t *testing.T) {
 	cairoRunConfig := cairo_run.CairoRunConfig{DisableTracePadding: false, Layout: "all_cairo", ProofMode: false}
 	_, err := cairo_run.CairoRun("../../../cairo_programs/factorial.json", cairoRunConfig)
@@ -0,0 +1,44 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/pkg/errors"
+)
+
+// Deserializes the hints of a Cairo 1 casm program. Unlike Cairo 0 programs, which store the code of each hint
+// inline, casm stores them as a list of `[offset, [hint, ...]]` pairs, where each hint is a json object such as
+// `{"AllocSegment": {"dst": {"register": "AP", "offset": 0}}}`.
+// Each hint is returned with its compacted json as code, and no references, as Cairo 1 hints don't use them
+func DeserializeHints(data []byte) (map[uint][]parser.HintParams, error) {
+	var entries [][2]json.RawMessage
+	err := json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, parser.ParserError(err)
+	}
+
+	hints := make(map[uint][]parser.HintParams, len(entries))
+	for _, entry := range entries {
+		var offset uint
+		err = json.Unmarshal(entry[0], &offset)
+		if err != nil {
+			return nil, parser.ParserError(errors.Wrapf(err, "Invalid hint offset %s", entry[0]))
+		}
+		var offsetHints []json.RawMessage
+		err = json.Unmarshal(entry[1], &offsetHints)
+		if err != nil {
+			return nil, parser.ParserError(errors.Wrapf(err, "Invalid hints at offset %d", offset))
+		}
+		for _, hint := range offsetHints {
+			var code bytes.Buffer
+			err = json.Compact(&code, hint)
+			if err != nil {
+				return nil, parser.ParserError(err)
+			}
+			hints[offset] = append(hints[offset], parser.HintParams{Code: code.String()})
+		}
+	}
+	return hints, nil
+}
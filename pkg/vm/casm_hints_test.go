@@ -0,0 +1,41 @@
+package vm_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+)
+
+func TestDeserializeHints(t *testing.T) {
+	data := []byte(`[
+		[0, [{"AllocSegment": {"dst": {"register": "AP", "offset": 0}}}]],
+		[5, [
+			{"TestLessThan": {"lhs": {"Deref": {"register": "AP", "offset": -1}}, "rhs": {"Immediate": "0x10"}, "dst": {"register": "AP", "offset": 0}}},
+			{"AllocSegment": {"dst": {"register": "AP", "offset": 1}}}
+		]]
+	]`)
+	hints, err := vm.DeserializeHints(data)
+	if err != nil {
+		t.Errorf("DeserializeHints failed with error: %s", err)
+		return
+	}
+	expected := map[uint][]parser.HintParams{
+		0: {{Code: `{"AllocSegment":{"dst":{"register":"AP","offset":0}}}`}},
+		5: {
+			{Code: `{"TestLessThan":{"lhs":{"Deref":{"register":"AP","offset":-1}},"rhs":{"Immediate":"0x10"},"dst":{"register":"AP","offset":0}}}`},
+			{Code: `{"AllocSegment":{"dst":{"register":"AP","offset":1}}}`},
+		},
+	}
+	if !reflect.DeepEqual(hints, expected) {
+		t.Errorf("Wrong hints. Expected %v, got %v", expected, hints)
+	}
+}
+
+func TestDeserializeHintsInvalidOffset(t *testing.T) {
+	_, err := vm.DeserializeHints([]byte(`[[-1, []]]`))
+	if err == nil {
+		t.Errorf("DeserializeHints should fail with a negative offset")
+	}
+}
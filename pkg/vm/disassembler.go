@@ -0,0 +1,128 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/pkg/errors"
+)
+
+// An instruction of the program's bytecode, along with its Cairo assembly representation
+type DecodedInstruction struct {
+	// Offset of the instruction in the program
+	Pc          uint
+	Instruction Instruction
+	// Value of the instruction's second word, nil if the instruction doesn't use an immediate
+	Immediate *lambdaworks.Felt
+	// Cairo assembly of the instruction, such as `[ap + 0] = [fp - 3] + 1, ap++`
+	Mnemonic string
+}
+
+// Decodes the program's data into its instructions. Instructions with an immediate operand take two felts.
+// Fails if the data contains values that aren't valid instructions (such as data embedded with `dw`)
+func (p *Program) Disassemble() ([]DecodedInstruction, error) {
+	instructions := make([]DecodedInstruction, 0, len(p.Data))
+	for pc := uint(0); pc < uint(len(p.Data)); {
+		encodedInstruction, ok := p.Data[pc].GetFelt()
+		if !ok {
+			return nil, errors.Errorf("Expected an instruction at pc %d, got %s", pc, p.Data[pc].String())
+		}
+		encoding, err := encodedInstruction.ToU64()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid instruction at pc %d", pc)
+		}
+		instruction, err := DecodeInstruction(encoding)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid instruction at pc %d", pc)
+		}
+
+		decoded := DecodedInstruction{Pc: pc, Instruction: instruction}
+		if instruction.Size() == 2 {
+			if pc+1 >= uint(len(p.Data)) {
+				return nil, errors.Errorf("Missing immediate of the instruction at pc %d", pc)
+			}
+			immediate, ok := p.Data[pc+1].GetFelt()
+			if !ok {
+				return nil, errors.Errorf("Expected a felt immediate at pc %d, got %s", pc+1, p.Data[pc+1].String())
+			}
+			decoded.Immediate = &immediate
+		}
+		decoded.Mnemonic = instructionMnemonic(&instruction, decoded.Immediate)
+		instructions = append(instructions, decoded)
+		pc += instruction.Size()
+	}
+	return instructions, nil
+}
+
+// Returns the Cairo assembly representation of the instruction
+func instructionMnemonic(instruction *Instruction, immediate *lambdaworks.Felt) string {
+	dst := formatMemoryOperand(registerName(instruction.DstReg), instruction.Off0)
+	op0 := formatMemoryOperand(registerName(instruction.Op0Reg), instruction.Off1)
+	var op1 string
+	switch instruction.Op1Addr {
+	case Op1SrcImm:
+		op1 = immediate.ToSigned().String()
+	case Op1SrcAP:
+		op1 = formatMemoryOperand("ap", instruction.Off2)
+	case Op1SrcFP:
+		op1 = formatMemoryOperand("fp", instruction.Off2)
+	case Op1SrcOp0:
+		op1 = formatMemoryOperand(op0, instruction.Off2)
+	}
+	var res string
+	switch instruction.ResLogic {
+	case ResOp1:
+		res = op1
+	case ResAdd:
+		res = op0 + " + " + op1
+	case ResMul:
+		res = op0 + " * " + op1
+	}
+
+	var mnemonic string
+	switch instruction.Opcode {
+	case AssertEq:
+		mnemonic = dst + " = " + res
+	case Call:
+		if instruction.PcUpdate == PcUpdateJumpRel {
+			mnemonic = "call rel " + res
+		} else {
+			mnemonic = "call abs " + res
+		}
+	case Ret:
+		mnemonic = "ret"
+	default:
+		switch instruction.PcUpdate {
+		case PcUpdateJump:
+			mnemonic = "jmp abs " + res
+		case PcUpdateJumpRel:
+			mnemonic = "jmp rel " + res
+		case PcUpdateJnz:
+			mnemonic = "jmp rel " + op1 + " if " + dst + " != 0"
+		default:
+			if instruction.ApUpdate == ApUpdateAdd {
+				return "ap += " + res
+			}
+			mnemonic = "nop"
+		}
+	}
+	if instruction.ApUpdate == ApUpdateAdd1 {
+		mnemonic += ", ap++"
+	}
+	return mnemonic
+}
+
+func registerName(register Register) string {
+	if register == FP {
+		return "fp"
+	}
+	return "ap"
+}
+
+// Formats a memory access such as `[ap + 1]` or `[fp - 3]`
+func formatMemoryOperand(base string, offset int) string {
+	if offset < 0 {
+		return fmt.Sprintf("[%s - %d]", base, -offset)
+	}
+	return fmt.Sprintf("[%s + %d]", base, offset)
+}
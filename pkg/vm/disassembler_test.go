@@ -0,0 +1,52 @@
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+)
+
+func TestDisassembleTinyProgram(t *testing.T) {
+	data := []uint64{
+		0x480680017fff8000, 1, // [ap + 0] = 1, ap++
+		0x1104800180018000, 3, // call rel 3
+		0x10780017fff7fff, 0, // jmp rel 0
+		0x208b7fff7fff7ffe, // ret
+	}
+	program := vm.Program{}
+	for _, value := range data {
+		program.Data = append(program.Data, *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(value)))
+	}
+	instructions, err := program.Disassemble()
+	if err != nil {
+		t.Errorf("Disassemble failed with error: %s", err)
+		return
+	}
+	expectedPcs := []uint{0, 2, 4, 6}
+	expectedMnemonics := []string{"[ap + 0] = 1, ap++", "call rel 3", "jmp rel 0", "ret"}
+	if len(instructions) != len(expectedMnemonics) {
+		t.Errorf("Wrong amount of instructions. Expected %d, got %d", len(expectedMnemonics), len(instructions))
+		return
+	}
+	for i, instruction := range instructions {
+		if instruction.Pc != expectedPcs[i] || instruction.Mnemonic != expectedMnemonics[i] {
+			t.Errorf("Wrong instruction %d. Expected %q at pc %d, got %q at pc %d", i, expectedMnemonics[i], expectedPcs[i], instruction.Mnemonic, instruction.Pc)
+		}
+	}
+	if instructions[3].Immediate != nil {
+		t.Errorf("ret should have no immediate")
+	}
+	if instructions[1].Immediate == nil || *instructions[1].Immediate != lambdaworks.FeltFromUint64(3) {
+		t.Errorf("Wrong immediate for call rel 3")
+	}
+}
+
+func TestDisassembleMissingImmediate(t *testing.T) {
+	program := vm.Program{Data: []memory.MaybeRelocatable{*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480680017fff8000))}}
+	_, err := program.Disassemble()
+	if err == nil {
+		t.Errorf("Disassemble should fail when an immediate is missing")
+	}
+}
@@ -1,9 +1,12 @@
 package vm
 
 import (
+	"fmt"
+
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
 	"github.com/lambdaclass/cairo-vm.go/pkg/types"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
 )
 
 type HintProcessor interface {
@@ -12,3 +15,25 @@ type HintProcessor interface {
 	// Executes the hint which's data is provided by a dynamic structure previously created by CompileHint
 	ExecuteHint(vm *VirtualMachine, hintData *any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error
 }
+
+// Can be implemented by the hint data created by CompileHint so that
+// errors raised by the hint can report the hint's code
+type HintCodeProvider interface {
+	HintCode() string
+}
+
+// Error returned by Step when the execution of a hint fails
+type HintError struct {
+	// Code of the failing hint, empty if the hint data doesn't implement HintCodeProvider
+	Code  string
+	Pc    memory.Relocatable
+	Cause error
+}
+
+func (e *HintError) Error() string {
+	return fmt.Sprintf("Hint error at pc (%d, %d): %s\nHint code:\n%s", e.Pc.SegmentIndex, e.Pc.Offset, e.Cause, e.Code)
+}
+
+func (e *HintError) Unwrap() error {
+	return e.Cause
+}
@@ -2,6 +2,7 @@ package memory
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	"github.com/pkg/errors"
@@ -25,8 +26,14 @@ func (set AddressSet) Contains(element Relocatable) bool {
 // A function that validates a memory address and returns a list of validated addresses
 type ValidationRule func(*Memory, Relocatable) ([]Relocatable, error)
 
+// A function called when a value is stored into a watched memory cell. As cells are write once, old is always nil
+type WatchpointCallback func(old *MaybeRelocatable, new *MaybeRelocatable)
+
 // Memory represents the Cairo VM's memory.
 type Memory struct {
+	// Cells of the segments using the default map backing, and the sparse cells of dense segments.
+	// Reading it directly misses the cells held by dense segments (see UseDenseBacking), use Get or
+	// SortedAddresses to access every cell
 	Data              map[Relocatable]MaybeRelocatable
 	numSegments       uint
 	validationRules   map[uint]ValidationRule
@@ -35,21 +42,125 @@ type Memory struct {
 	// The map is of the form `segmentIndex` -> `offset`. This is to
 	// make the counting of memory holes easier
 	AccessedAddresses map[Relocatable]bool
+	// Maximum amount of cells that can be inserted, zero means unlimited
+	maxCells uint
+	// Callbacks of the watched addresses, called by Insert
+	watchpoints map[Relocatable]WatchpointCallback
+	// Segments stored in a dense backing instead of the Data map, indexed by segment index
+	denseSegments map[uint]*denseSegment
+}
+
+// Amount of offsets a dense segment can always grow past its current length when writing a single cell.
+// Beyond it, a cell is only stored densely if doing so at most doubles the length of the segment
+const denseSegmentMinGrowth = 1024
+
+// Backing store of a segment whose cells are mostly contiguous, indexed by offset.
+// It takes less space than the Data map for such segments. Cells written too far past its end are
+// rejected by set, and stored in the Data map instead, so that sparse writes don't grow it unboundedly
+type denseSegment struct {
+	values []MaybeRelocatable
+	// Whether each offset holds a value, as the segment can still have holes
+	present []bool
+	// Amount of offsets that hold a value
+	numCells uint
+}
+
+func (d *denseSegment) get(offset uint) (MaybeRelocatable, bool) {
+	if offset >= uint(len(d.values)) || !d.present[offset] {
+		return MaybeRelocatable{}, false
+	}
+	return d.values[offset], true
+}
+
+// Stores the value at offset, returns false without storing it if the offset is too far past the end of the segment
+func (d *denseSegment) set(offset uint, value MaybeRelocatable) bool {
+	if offset >= 2*uint(len(d.values))+denseSegmentMinGrowth {
+		return false
+	}
+	if offset >= uint(len(d.values)) {
+		growth := offset + 1 - uint(len(d.values))
+		d.values = append(d.values, make([]MaybeRelocatable, growth)...)
+		d.present = append(d.present, make([]bool, growth)...)
+	}
+	if !d.present[offset] {
+		d.present[offset] = true
+		d.numCells++
+	}
+	d.values[offset] = value
+	return true
+}
+
+func (d *denseSegment) clone() *denseSegment {
+	return &denseSegment{
+		values:   append([]MaybeRelocatable(nil), d.values...),
+		present:  append([]bool(nil), d.present...),
+		numCells: d.numCells,
+	}
 }
 
 var ErrMissingSegmentUsize = errors.New("Segment effective sizes haven't been calculated")
 var ErrInsufficientAllocatedCells = errors.New("Insufficient Allocated Memory Cells")
+var ErrMaxCellsExceeded = errors.New("Maximum amount of memory cells exceeded")
+var ErrMemoryWriteOnce = errors.New("Memory is write-once, cannot overwrite memory value")
+var ErrUnknownMemory = errors.New("Unknown memory cell")
+var ErrExpectedFelt = errors.New("Expected Felt value in memory")
+var ErrExpectedRelocatable = errors.New("Expected Relocatable value in memory")
+var ErrValueOutOfRange = errors.New("Memory value out of range")
+
+// Kinds of InsufficientAllocatedCells errors
+const (
+	// A builtin used more cells than were allocated for it
+	INSUFFICIENT_CELLS_BUILTIN = "builtin"
+	// The run didn't reach the minimum amount of steps needed by a builtin
+	INSUFFICIENT_CELLS_MIN_STEP = "min_step"
+	// Not enough unused memory units to fill the memory holes
+	INSUFFICIENT_CELLS_MEMORY_UNITS = "memory_units"
+	// Not enough unused diluted units for the diluted pool
+	INSUFFICIENT_CELLS_DILUTED_UNITS = "diluted_units"
+	// Not enough unused range check units for the range of checked values
+	INSUFFICIENT_CELLS_RANGE_CHECK_UNITS = "range_check_units"
+)
+
+// Error returned when the cells allocated for a run don't fit its usage.
+// Builtin is empty for kinds not related to a single builtin.
+// For INSUFFICIENT_CELLS_MIN_STEP errors, Allocated holds the minimum step and Used is zero.
+// Unwraps to ErrInsufficientAllocatedCells
+type InsufficientAllocatedCells struct {
+	Kind      string
+	Builtin   string
+	Used      uint
+	Allocated uint
+}
+
+func (e InsufficientAllocatedCells) Error() string {
+	switch {
+	case e.Kind == INSUFFICIENT_CELLS_MIN_STEP:
+		return fmt.Sprintf("%s, Min Step not reached. minStep: %d, builtin: %s", ErrInsufficientAllocatedCells, e.Allocated, e.Builtin)
+	case e.Builtin != "":
+		return fmt.Sprintf("%s, builtin: %s, used: %d, size: %d", ErrInsufficientAllocatedCells, e.Builtin, e.Used, e.Allocated)
+	default:
+		return fmt.Sprintf("%s, %s used: %d, size: %d", ErrInsufficientAllocatedCells, e.Kind, e.Used, e.Allocated)
+	}
+}
+
+func (e InsufficientAllocatedCells) Unwrap() error {
+	return ErrInsufficientAllocatedCells
+}
+
+func UnknownMemoryError(addr Relocatable) error {
+	return fmt.Errorf("%w at address (%d, %d)", ErrUnknownMemory, addr.SegmentIndex, addr.Offset)
+}
 
-func InsufficientAllocatedCellsErrorWithBuiltinName(name string, used uint, size uint) error {
-	return fmt.Errorf("%w, builtin: %s, used: %d, size: %d", ErrInsufficientAllocatedCells, name, used, size)
+func ExpectedFeltError(addr Relocatable) error {
+	return fmt.Errorf("%w at address (%d, %d)", ErrExpectedFelt, addr.SegmentIndex, addr.Offset)
 }
 
-func InsufficientAllocatedCellsError(used uint, size uint) error {
-	return fmt.Errorf("%w, used: %d, size: %d", ErrInsufficientAllocatedCells, used, size)
+func ExpectedRelocatableError(addr Relocatable) error {
+	return fmt.Errorf("%w at address (%d, %d)", ErrExpectedRelocatable, addr.SegmentIndex, addr.Offset)
 }
 
-func InsufficientAllocatedCellsErrorMinStepNotReached(minStep uint, builtinName string) error {
-	return fmt.Errorf("%w, Min Step not reached. minStep: %d, builtin: %s", ErrInsufficientAllocatedCells, minStep, builtinName)
+func ValueOutOfRangeError(addr Relocatable, value lambdaworks.Felt, maxBits uint) error {
+	return fmt.Errorf("%w at address (%d, %d), value %s doesn't fit in %d bits", ErrValueOutOfRange, addr.SegmentIndex, addr.Offset, value.ToHexString(), maxBits)
 }
 
 func NewMemory() *Memory {
@@ -65,6 +176,164 @@ func (m *Memory) NumSegments() uint {
 	return m.numSegments
 }
 
+// Stores the cells of the given segment in a dense array instead of the Data map, which is more compact for
+// segments known to be contiguous, such as the program segment. Capacity preallocates room for that many cells.
+// Cells the segment already holds are moved to the new backing, except for the ones too far apart from the rest,
+// which stay in the Data map like any sparse cell later written to the segment. Fails if the segment wasn't allocated
+func (m *Memory) UseDenseBacking(segmentIndex uint, capacity uint) error {
+	if segmentIndex >= m.numSegments {
+		return errors.Wrapf(ErrSegmentNotFound, "segment %d", segmentIndex)
+	}
+	if _, dense := m.denseSegments[segmentIndex]; dense {
+		return nil
+	}
+	segment := &denseSegment{
+		values:  make([]MaybeRelocatable, 0, capacity),
+		present: make([]bool, 0, capacity),
+	}
+	var addresses []Relocatable
+	for addr := range m.Data {
+		if addr.SegmentIndex == int(segmentIndex) {
+			addresses = append(addresses, addr)
+		}
+	}
+	// Moving the cells by offset keeps the ones close to the start of the segment dense
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Offset < addresses[j].Offset
+	})
+	for _, addr := range addresses {
+		if segment.set(addr.Offset, m.Data[addr]) {
+			delete(m.Data, addr)
+		}
+	}
+	if m.denseSegments == nil {
+		m.denseSegments = make(map[uint]*denseSegment)
+	}
+	m.denseSegments[segmentIndex] = segment
+	return nil
+}
+
+// Returns the value stored at addr, dispatching on the backing of its segment
+func (m *Memory) lookup(addr Relocatable) (MaybeRelocatable, bool) {
+	if addr.SegmentIndex >= 0 {
+		if segment, dense := m.denseSegments[uint(addr.SegmentIndex)]; dense {
+			if value, ok := segment.get(addr.Offset); ok {
+				return value, true
+			}
+		}
+	}
+	value, ok := m.Data[addr]
+	return value, ok
+}
+
+// Stores the value at addr, dispatching on the backing of its segment. The address must be empty
+func (m *Memory) store(addr Relocatable, value MaybeRelocatable) {
+	if addr.SegmentIndex >= 0 {
+		if segment, dense := m.denseSegments[uint(addr.SegmentIndex)]; dense && segment.set(addr.Offset, value) {
+			return
+		}
+	}
+	m.Data[addr] = value
+}
+
+// Calls f with every stored cell, in no particular order
+func (m *Memory) forEachCell(f func(addr Relocatable, value MaybeRelocatable)) {
+	for addr, value := range m.Data {
+		f(addr, value)
+	}
+	for segmentIndex, segment := range m.denseSegments {
+		for offset, present := range segment.present {
+			if present {
+				f(NewRelocatable(int(segmentIndex), uint(offset)), segment.values[offset])
+			}
+		}
+	}
+}
+
+// Returns the amount of stored cells
+func (m *Memory) numCells() uint {
+	numCells := uint(len(m.Data))
+	for _, segment := range m.denseSegments {
+		numCells += segment.numCells
+	}
+	return numCells
+}
+
+// Returns a deep copy of the memory, inserting into the copy doesn't affect the original.
+// Watchpoints are not copied, as their callbacks belong to whoever registered them on the original
+func (m *Memory) Clone() *Memory {
+	data := make(map[Relocatable]MaybeRelocatable, len(m.Data))
+	for addr, value := range m.Data {
+		data[addr] = value
+	}
+	validationRules := make(map[uint]ValidationRule, len(m.validationRules))
+	for segmentIndex, rule := range m.validationRules {
+		validationRules[segmentIndex] = rule
+	}
+	validatedAddresses := NewAddressSet()
+	for addr, validated := range m.validatedAdresses {
+		validatedAddresses[addr] = validated
+	}
+	accessedAddresses := make(map[Relocatable]bool, len(m.AccessedAddresses))
+	for addr, accessed := range m.AccessedAddresses {
+		accessedAddresses[addr] = accessed
+	}
+	var denseSegments map[uint]*denseSegment
+	if m.denseSegments != nil {
+		denseSegments = make(map[uint]*denseSegment, len(m.denseSegments))
+		for segmentIndex, segment := range m.denseSegments {
+			denseSegments[segmentIndex] = segment.clone()
+		}
+	}
+	return &Memory{
+		Data:              data,
+		numSegments:       m.numSegments,
+		validationRules:   validationRules,
+		validatedAdresses: validatedAddresses,
+		AccessedAddresses: accessedAddresses,
+		maxCells:          m.maxCells,
+		denseSegments:     denseSegments,
+	}
+}
+
+// Compares the values held by two memories.
+// Returns true if both hold the same values at the same addresses, otherwise returns false and the
+// first address (ordered by segment index and offset) where they differ, including addresses that
+// hold a value in one memory and are a hole in the other
+func (m *Memory) Equals(other *Memory) (bool, Relocatable) {
+	var firstDiff *Relocatable
+	checkAddr := func(addr Relocatable, _ MaybeRelocatable) {
+		if firstDiff != nil && (addr.SegmentIndex > firstDiff.SegmentIndex ||
+			(addr.SegmentIndex == firstDiff.SegmentIndex && addr.Offset >= firstDiff.Offset)) {
+			return
+		}
+		value, ok := m.lookup(addr)
+		otherValue, otherOk := other.lookup(addr)
+		if ok != otherOk || (ok && !value.IsEqual(&otherValue)) {
+			diff := addr
+			firstDiff = &diff
+		}
+	}
+	m.forEachCell(checkAddr)
+	other.forEachCell(checkAddr)
+	if firstDiff == nil {
+		return true, Relocatable{}
+	}
+	return false, *firstDiff
+}
+
+// Sets the maximum amount of cells that can be inserted into memory
+// Inserting a new cell once the limit is reached will fail with ErrMaxCellsExceeded
+// A value of zero removes the limit
+func (m *Memory) SetMaxCells(n uint) {
+	m.maxCells = n
+}
+
+// Returns the maximum amount of cells that can be inserted into memory, zero means unlimited
+func (m *Memory) MaxCells() uint {
+	return m.maxCells
+}
+
 // Inserts a value in some memory address, given by a Relocatable value.
 func (m *Memory) Insert(addr Relocatable, val *MaybeRelocatable) error {
 	// FIXME: There should be a special handling if the key
@@ -80,15 +349,49 @@ func (m *Memory) Insert(addr Relocatable, val *MaybeRelocatable) error {
 	}
 
 	// Check for possible overwrites
-	prev_elem, ok := m.Data[addr]
+	prev_elem, ok := m.lookup(addr)
 	if ok && prev_elem != *val {
-		return errors.New("Memory is write-once, cannot overwrite memory value")
+		return ErrMemoryWriteOnce
+	}
+	// Check that the insertion doesn't exceed the cell limit
+	if !ok && m.maxCells != 0 && m.numCells() >= m.maxCells {
+		return ErrMaxCellsExceeded
+	}
+	if !ok {
+		m.store(addr, *val)
+		if callback, watched := m.watchpoints[addr]; watched {
+			callback(nil, val)
+		}
+	}
+	return m.validateAddress(addr)
+}
+
+// Registers a callback that is called once a value is stored into addr, replacing any previous one.
+// Rewriting the value the cell already holds or attempting to overwrite it with a different value doesn't call it,
+// as nothing is stored
+func (m *Memory) AddWatchpoint(addr Relocatable, callback WatchpointCallback) {
+	if m.watchpoints == nil {
+		m.watchpoints = make(map[Relocatable]WatchpointCallback)
+	}
+	m.watchpoints[addr] = callback
+}
+
+// Inserts a value in some memory address unless that address already holds the same value, in which case
+// the cell isn't written again but is still validated if it wasn't already. Used to store deduced operands.
+// Fails with ErrMemoryWriteOnce if it holds a different value
+func (m *Memory) InsertIfAbsent(addr Relocatable, val *MaybeRelocatable) error {
+	prev_elem, ok := m.lookup(addr)
+	if !ok {
+		return m.Insert(addr, val)
+	}
+	if prev_elem != *val {
+		return ErrMemoryWriteOnce
 	}
-	m.Data[addr] = *val
 	return m.validateAddress(addr)
 }
 
 // Gets some value stored in the memory address `addr`.
+// Fails with ErrUnknownMemory if there is no value at that address
 func (m *Memory) Get(addr Relocatable) (*MaybeRelocatable, error) {
 	// FIXME: There should be a special handling if the key
 	// segment index is negative. This is an edge
@@ -102,29 +405,99 @@ func (m *Memory) Get(addr Relocatable) (*MaybeRelocatable, error) {
 	// check if the value is a `Relocatable` with a negative
 	// segment index. Again, these are edge cases so not important
 	// right now. See cairo-vm code for details.
-	value, ok := m.Data[addr]
+	value, ok := m.lookup(addr)
 
 	if !ok {
-		return nil, errors.New("Memory Get: Value not found")
+		return nil, UnknownMemoryError(addr)
 	}
 
 	return &value, nil
 }
 
+// Returns the values stored in the given segment, ordered by offset
 func (memory *Memory) GetSegment(segmentIndex int) []MaybeRelocatable {
 	var ret []MaybeRelocatable
 
-	for address, value := range memory.Data {
-		if address.SegmentIndex == segmentIndex {
-			ret = append(ret, value)
-		}
+	for _, address := range memory.SegmentAddresses(segmentIndex) {
+		value, _ := memory.lookup(address)
+		ret = append(ret, value)
 	}
 
 	return ret
 }
 
+// Returns whether the segment holds a value in every offset up to its last one.
+// If it doesn't, the address of its first hole is returned as well. Empty segments are considered continuous
+func (m *Memory) IsSegmentContinuous(index uint) (bool, *Relocatable) {
+	var numCells, size uint
+	m.forEachCell(func(addr Relocatable, _ MaybeRelocatable) {
+		if addr.SegmentIndex == int(index) {
+			numCells++
+			if addr.Offset+1 > size {
+				size = addr.Offset + 1
+			}
+		}
+	})
+	if numCells == size {
+		return true, nil
+	}
+	for offset := uint(0); offset < size; offset++ {
+		hole := NewRelocatable(int(index), offset)
+		if _, ok := m.lookup(hole); !ok {
+			return false, &hole
+		}
+	}
+	return true, nil
+}
+
+// Returns the addresses that hold a value, ordered by segment index and offset.
+// Iterating over them instead of over the Data map gives a deterministic order
+func (m *Memory) SortedAddresses() []Relocatable {
+	addresses := make([]Relocatable, 0, m.numCells())
+	m.forEachCell(func(addr Relocatable, _ MaybeRelocatable) {
+		addresses = append(addresses, addr)
+	})
+	sort.Slice(addresses, func(i, j int) bool {
+		if addresses[i].SegmentIndex != addresses[j].SegmentIndex {
+			return addresses[i].SegmentIndex < addresses[j].SegmentIndex
+		}
+		return addresses[i].Offset < addresses[j].Offset
+	})
+	return addresses
+}
+
+// Returns the addresses of the given segment that hold a value, ordered by offset.
+// Only the cells of that segment are sorted, unlike filtering the result of SortedAddresses
+func (m *Memory) SegmentAddresses(segmentIndex int) []Relocatable {
+	var addresses []Relocatable
+	if segmentIndex >= 0 {
+		if segment, dense := m.denseSegments[uint(segmentIndex)]; dense {
+			addresses = make([]Relocatable, 0, segment.numCells)
+			for offset, present := range segment.present {
+				if present {
+					addresses = append(addresses, NewRelocatable(segmentIndex, uint(offset)))
+				}
+			}
+		}
+	}
+	denseCells := len(addresses)
+	for addr := range m.Data {
+		if addr.SegmentIndex == segmentIndex {
+			addresses = append(addresses, addr)
+		}
+	}
+	if len(addresses) == denseCells {
+		// Cells of a dense segment are already ordered by offset
+		return addresses
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Offset < addresses[j].Offset
+	})
+	return addresses
+}
+
 // Gets the felt value stored in the memory address `addr`.
-// Fails if the value doesn't exist or is not a felt
+// Fails with ErrUnknownMemory if the value doesn't exist, or ErrExpectedFelt if it is not a felt
 func (m *Memory) GetFelt(addr Relocatable) (lambdaworks.Felt, error) {
 	elem, err := m.Get(addr)
 	if err == nil {
@@ -132,17 +505,64 @@ func (m *Memory) GetFelt(addr Relocatable) (lambdaworks.Felt, error) {
 		if ok {
 			return felt, nil
 		} else {
-			return lambdaworks.FeltZero(), errors.New("Memory GetFelt: Fetched value is not a Felt")
+			return lambdaworks.FeltZero(), ExpectedFeltError(addr)
 		}
 	}
 	return lambdaworks.FeltZero(), err
 }
 
+// Gets the felt value stored in the memory address `addr` without building errors, for hot loops over large ranges.
+// Returns false if the address is empty, temporary, or holds a relocatable.
+// Callers are expected to know the range they read is valid, as failures carry no information
+func (m *Memory) GetFeltUnchecked(addr Relocatable) (lambdaworks.Felt, bool) {
+	if addr.SegmentIndex < 0 {
+		return lambdaworks.Felt{}, false
+	}
+	value, ok := m.lookup(addr)
+	if !ok {
+		return lambdaworks.Felt{}, false
+	}
+	return value.GetFelt()
+}
+
+// Gets the felt value stored in the memory address `addr`, checking that it fits in maxBits bits.
+// Fails like GetFelt, or with ErrValueOutOfRange if the value needs more than maxBits bits
+func (m *Memory) GetInteger(addr Relocatable, maxBits uint) (lambdaworks.Felt, error) {
+	felt, err := m.GetFelt(addr)
+	if err != nil {
+		return lambdaworks.FeltZero(), err
+	}
+	if uint(felt.Bits()) > maxBits {
+		return lambdaworks.FeltZero(), ValueOutOfRangeError(addr, felt, maxBits)
+	}
+	return felt, nil
+}
+
 // Adds a validation rule for a given segment
 func (m *Memory) AddValidationRule(SegmentIndex uint, rule ValidationRule) {
 	m.validationRules[SegmentIndex] = rule
 }
 
+// Returns true if the given segment has a validation rule
+func (m *Memory) HasValidationRule(segmentIndex uint) bool {
+	_, ok := m.validationRules[segmentIndex]
+	return ok
+}
+
+// Removes every validation rule, so that the memory can be reused without them.
+// Addresses that were already validated remain so
+func (m *Memory) ClearValidationRules() {
+	m.validationRules = make(map[uint]ValidationRule)
+}
+
+// Removes the validation rule of the given segment, if any
+func (m *Memory) RemoveValidationRule(segment int) {
+	if segment < 0 {
+		return
+	}
+	delete(m.validationRules, uint(segment))
+}
+
 // Applies the validation rule for the addr's segment if any
 // Skips validation if the address is temporary or if it has been previously validated
 func (m *Memory) validateAddress(addr Relocatable) error {
@@ -170,15 +590,32 @@ func (m *Memory) MarkAsAccessed(address Relocatable) {
 // Applies validation_rules to every memory address, if applicatble
 // Skips validation if the address is temporary or if it has been previously validated
 func (m *Memory) ValidateExistingMemory() error {
-	for addr := range m.Data {
-		err := m.validateAddress(addr)
-		if err != nil {
+	var err error
+	m.forEachCell(func(addr Relocatable, _ MaybeRelocatable) {
+		if err == nil {
+			err = m.validateAddress(addr)
+		}
+	})
+	return err
+}
+
+// Applies validation_rules to the n cells starting at start, skipping empty cells
+// Skips validation if the address is temporary or if it has been previously validated
+func (m *Memory) ValidateRange(start Relocatable, n uint) error {
+	for i := uint(0); i < n; i++ {
+		addr := start.AddUint(i)
+		if _, ok := m.lookup(addr); !ok {
+			continue
+		}
+		if err := m.validateAddress(addr); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// Gets the relocatable value stored in the memory address `key`.
+// Fails with ErrUnknownMemory if the value doesn't exist, or ErrExpectedRelocatable if it is not a relocatable
 func (m *Memory) GetRelocatable(key Relocatable) (Relocatable, error) {
 	memoryValue, err := m.Get(key)
 	if err != nil {
@@ -187,8 +624,61 @@ func (m *Memory) GetRelocatable(key Relocatable) (Relocatable, error) {
 
 	ret, isRelocatable := memoryValue.GetRelocatable()
 	if !isRelocatable {
-		return Relocatable{}, errors.Errorf("Expected Relocatable value in memory at address (%d, %d)", key.SegmentIndex, key.Offset)
+		return Relocatable{}, ExpectedRelocatableError(key)
 	}
 
 	return ret, nil
 }
+
+// Returns the n cells starting at start, with nil entries for the cells that hold no value
+func (m *Memory) GetRange(start Relocatable, n uint) ([]*MaybeRelocatable, error) {
+	values := make([]*MaybeRelocatable, 0, n)
+	for i := uint(0); i < n; i++ {
+		value, err := m.Get(start.AddUint(i))
+		if errors.Is(err, ErrUnknownMemory) {
+			values = append(values, nil)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// Returns the n cells starting at start.
+// Fails with ErrUnknownMemory if any of them holds no value
+func (m *Memory) GetContinuousRange(start Relocatable, n uint) ([]MaybeRelocatable, error) {
+	values := make([]MaybeRelocatable, 0, n)
+	for i := uint(0); i < n; i++ {
+		value, err := m.Get(start.AddUint(i))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, *value)
+	}
+	return values, nil
+}
+
+// Returns the n felts starting at start.
+// Fails with ErrUnknownMemory if any of the cells holds no value, or ErrExpectedFelt if any of them holds a relocatable
+func (m *Memory) GetFeltRange(start Relocatable, n uint) ([]lambdaworks.Felt, error) {
+	values, err := m.GetRange(start, n)
+	if err != nil {
+		return nil, err
+	}
+	felts := make([]lambdaworks.Felt, 0, n)
+	for i, value := range values {
+		addr := start.AddUint(uint(i))
+		if value == nil {
+			return nil, UnknownMemoryError(addr)
+		}
+		felt, ok := value.GetFelt()
+		if !ok {
+			return nil, ExpectedFeltError(addr)
+		}
+		felts = append(felts, felt)
+	}
+	return felts, nil
+}
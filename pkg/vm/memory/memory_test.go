@@ -3,6 +3,7 @@ package memory_test
 import (
 	"errors"
 	"reflect"
+	"runtime"
 	"testing"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
@@ -69,6 +70,43 @@ func TestMemoryInsertWithValidationRulesErr(t *testing.T) {
 	}
 }
 
+func TestMemoryInsertAfterClearValidationRules(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	mem.AddValidationRule(0, rule_always_err)
+
+	key := memory.NewRelocatable(0, 0)
+	val := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5))
+	if err := mem.Insert(key, val); err == nil {
+		t.Errorf("Insertion should have failed due to validation rule")
+		return
+	}
+
+	mem.ClearValidationRules()
+	if err := mem.Insert(key, val); err != nil {
+		t.Errorf("Insertion failed after clearing validation rules: %s", err)
+	}
+}
+
+func TestMemoryInsertAfterRemoveValidationRule(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	mem.AddValidationRule(0, rule_always_err)
+	mem.AddValidationRule(1, rule_always_err)
+
+	mem.RemoveValidationRule(0)
+	val := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5))
+	if err := mem.Insert(memory.NewRelocatable(0, 0), val); err != nil {
+		t.Errorf("Insertion failed after removing the segment's validation rule: %s", err)
+	}
+	if err := mem.Insert(memory.NewRelocatable(1, 0), val); err == nil {
+		t.Errorf("Insertion should have failed due to the remaining validation rule")
+	}
+}
+
 func TestMemoryInsert(t *testing.T) {
 	mem_manager := memory.NewMemorySegmentManager()
 	mem_manager.AddSegment()
@@ -132,7 +170,7 @@ func TestMemoryInsertOverWriteSameValue(t *testing.T) {
 	mem := &mem_manager.Memory
 
 	// We will insert the MaybeRelocatable Felt(7) in segment 0, offset 0
-	key := mem_manager.AddSegment()
+	key, _ := mem_manager.AddSegment()
 	val := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7))
 
 	// Make the insertion
@@ -153,7 +191,7 @@ func TestMemoryInsertOverWriteValue(t *testing.T) {
 	mem := &mem_manager.Memory
 
 	// We will insert the MaybeRelocatable Felt(7) in segment 0, offset 0
-	key := mem_manager.AddSegment()
+	key, _ := mem_manager.AddSegment()
 	val := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7))
 
 	// Make the insertion
@@ -395,6 +433,57 @@ func TestMemoryValidateExistingMemoryErr(t *testing.T) {
 	}
 }
 
+func TestMemoryValidateRangeOnlyValidatesRange(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	// Load Values to memory
+	for i := uint(0); i < 10; i++ {
+		key := memory.NewRelocatable(0, i)
+		val := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(i)))
+		err := mem.Insert(key, val)
+		if err != nil {
+			t.Errorf("Insert error in test: %s", err)
+		}
+	}
+	// Add a validation rule for segment 0 that records the addresses it validates
+	validated := make([]memory.Relocatable, 0)
+	mem.AddValidationRule(0, func(_ *memory.Memory, addr memory.Relocatable) ([]memory.Relocatable, error) {
+		validated = append(validated, addr)
+		return []memory.Relocatable{addr}, nil
+	})
+	// Run ValidateRange over cells 3 to 5
+	err := mem.ValidateRange(memory.NewRelocatable(0, 3), 3)
+	if err != nil {
+		t.Errorf("ValidateRange error in test: %s", err)
+		return
+	}
+	expected := []memory.Relocatable{memory.NewRelocatable(0, 3), memory.NewRelocatable(0, 4), memory.NewRelocatable(0, 5)}
+	if !reflect.DeepEqual(validated, expected) {
+		t.Errorf("Wrong validated addresses. Expected %+v, got %+v", expected, validated)
+	}
+}
+
+func TestMemoryValidateRangeErr(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	key := memory.NewRelocatable(0, 2)
+	val := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2))
+	err := mem.Insert(key, val)
+	if err != nil {
+		t.Errorf("Insert error in test: %s", err)
+	}
+	mem.AddValidationRule(0, rule_always_err)
+	// Cells outside the range or empty ones aren't validated
+	if err := mem.ValidateRange(memory.NewRelocatable(0, 0), 2); err != nil {
+		t.Errorf("ValidateRange error in test: %s", err)
+	}
+	if err := mem.ValidateRange(memory.NewRelocatable(0, 0), 3); err == nil {
+		t.Errorf("ValidateRange should have failed")
+	}
+}
+
 func TestValidateMemoryForInvalidSignature(t *testing.T) {
 	builtin := builtins.NewSignatureBuiltinRunner(2048)
 	mem_manager := memory.NewMemorySegmentManager()
@@ -457,3 +546,689 @@ func TestValidateMemoryForValidSignature(t *testing.T) {
 		t.Errorf("ValidateExistingMemory error in test: %s", err)
 	}
 }
+
+func TestMemoryInsertBelowMaxCells(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	mem.SetMaxCells(2)
+
+	for i := uint(0); i < 2; i++ {
+		err := mem.Insert(memory.NewRelocatable(0, i), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)))
+		if err != nil {
+			t.Errorf("Insert error in test: %s", err)
+		}
+	}
+	// Inserting the same value into an existing cell doesn't add a new cell
+	err := mem.Insert(memory.NewRelocatable(0, 1), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)))
+	if err != nil {
+		t.Errorf("Insert error in test: %s", err)
+	}
+}
+
+func TestMemoryInsertPastMaxCells(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	mem.SetMaxCells(2)
+
+	for i := uint(0); i < 2; i++ {
+		err := mem.Insert(memory.NewRelocatable(0, i), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)))
+		if err != nil {
+			t.Errorf("Insert error in test: %s", err)
+		}
+	}
+	err := mem.Insert(memory.NewRelocatable(0, 2), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)))
+	if err != memory.ErrMaxCellsExceeded {
+		t.Errorf("Insert should have failed with ErrMaxCellsExceeded, got: %v", err)
+	}
+}
+
+func TestMemoryEqualsSameValues(t *testing.T) {
+	mem := memory.NewMemory()
+	mem.Data[memory.NewRelocatable(0, 0)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5))
+	mem.Data[memory.NewRelocatable(1, 3)] = *memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(0, 0))
+	other := mem.Clone()
+
+	equal, _ := mem.Equals(other)
+	if !equal {
+		t.Errorf("Memories with the same values should be equal")
+	}
+}
+
+func TestMemoryEqualsDifferentValues(t *testing.T) {
+	mem := memory.NewMemory()
+	mem.Data[memory.NewRelocatable(0, 0)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5))
+	mem.Data[memory.NewRelocatable(0, 1)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(6))
+	mem.Data[memory.NewRelocatable(0, 2)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7))
+	other := mem.Clone()
+	other.Data[memory.NewRelocatable(0, 2)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(8))
+	other.Data[memory.NewRelocatable(0, 1)] = *memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(0, 0))
+
+	equal, addr := mem.Equals(other)
+	if equal || addr != memory.NewRelocatable(0, 1) {
+		t.Errorf("Expected memories to differ first at (0, 1), got equal: %t, address: %v", equal, addr)
+	}
+}
+
+func TestMemoryEqualsValueAgainstHole(t *testing.T) {
+	mem := memory.NewMemory()
+	mem.Data[memory.NewRelocatable(0, 0)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5))
+	other := mem.Clone()
+	other.Data[memory.NewRelocatable(1, 4)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5))
+
+	equal, addr := mem.Equals(other)
+	if equal || addr != memory.NewRelocatable(1, 4) {
+		t.Errorf("Expected memories to differ at (1, 4), got equal: %t, address: %v", equal, addr)
+	}
+	equal, addr = other.Equals(mem)
+	if equal || addr != memory.NewRelocatable(1, 4) {
+		t.Errorf("Expected memories to differ at (1, 4), got equal: %t, address: %v", equal, addr)
+	}
+}
+
+func TestMemoryGetUnknownMemory(t *testing.T) {
+	mem := memory.NewMemory()
+	addr := memory.NewRelocatable(0, 0)
+	_, err := mem.Get(addr)
+	if !errors.Is(err, memory.ErrUnknownMemory) {
+		t.Errorf("Get: expected ErrUnknownMemory, got %v", err)
+	}
+	_, err = mem.GetFelt(addr)
+	if !errors.Is(err, memory.ErrUnknownMemory) {
+		t.Errorf("GetFelt: expected ErrUnknownMemory, got %v", err)
+	}
+	_, err = mem.GetRelocatable(addr)
+	if !errors.Is(err, memory.ErrUnknownMemory) {
+		t.Errorf("GetRelocatable: expected ErrUnknownMemory, got %v", err)
+	}
+}
+
+func TestMemoryGetFeltExpectedFelt(t *testing.T) {
+	mem := memory.NewMemory()
+	addr := memory.NewRelocatable(0, 0)
+	mem.Data[addr] = *memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 0))
+	_, err := mem.GetFelt(addr)
+	if !errors.Is(err, memory.ErrExpectedFelt) {
+		t.Errorf("Expected ErrExpectedFelt, got %v", err)
+	}
+}
+
+func TestMemoryGetRelocatableExpectedRelocatable(t *testing.T) {
+	mem := memory.NewMemory()
+	addr := memory.NewRelocatable(0, 0)
+	mem.Data[addr] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(3))
+	_, err := mem.GetRelocatable(addr)
+	if !errors.Is(err, memory.ErrExpectedRelocatable) {
+		t.Errorf("Expected ErrExpectedRelocatable, got %v", err)
+	}
+}
+
+func TestMemoryGetRangeWithHoles(t *testing.T) {
+	mem := memory.NewMemory()
+	mem.Data[memory.NewRelocatable(0, 0)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1))
+	mem.Data[memory.NewRelocatable(0, 2)] = *memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 0))
+
+	values, err := mem.GetRange(memory.NewRelocatable(0, 0), 4)
+	if err != nil {
+		t.Errorf("GetRange failed with error: %s", err)
+	}
+	expected := []*memory.MaybeRelocatable{
+		memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)),
+		nil,
+		memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 0)),
+		nil,
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Wrong range. Expected %v, got %v", expected, values)
+	}
+}
+
+func TestMemoryGetContinuousRange(t *testing.T) {
+	mem := memory.NewMemory()
+	mem.Data[memory.NewRelocatable(0, 0)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1))
+	mem.Data[memory.NewRelocatable(0, 1)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2))
+
+	values, err := mem.GetContinuousRange(memory.NewRelocatable(0, 0), 2)
+	if err != nil {
+		t.Errorf("GetContinuousRange failed with error: %s", err)
+	}
+	expected := []memory.MaybeRelocatable{
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2)),
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Wrong range. Expected %v, got %v", expected, values)
+	}
+}
+
+func TestMemoryGetContinuousRangeWithHole(t *testing.T) {
+	mem := memory.NewMemory()
+	mem.Data[memory.NewRelocatable(0, 0)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1))
+	mem.Data[memory.NewRelocatable(0, 2)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(3))
+
+	_, err := mem.GetContinuousRange(memory.NewRelocatable(0, 0), 3)
+	if !errors.Is(err, memory.ErrUnknownMemory) {
+		t.Errorf("Expected ErrUnknownMemory, got %v", err)
+	}
+}
+
+func TestMemoryGetFeltRange(t *testing.T) {
+	mem := memory.NewMemory()
+	for i := uint(0); i < 4; i++ {
+		mem.Data[memory.NewRelocatable(0, i)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(i + 1)))
+	}
+
+	felts, err := mem.GetFeltRange(memory.NewRelocatable(0, 0), 4)
+	if err != nil {
+		t.Errorf("GetFeltRange failed with error: %s", err)
+	}
+	expected := []lambdaworks.Felt{
+		lambdaworks.FeltFromUint64(1),
+		lambdaworks.FeltFromUint64(2),
+		lambdaworks.FeltFromUint64(3),
+		lambdaworks.FeltFromUint64(4),
+	}
+	if !reflect.DeepEqual(felts, expected) {
+		t.Errorf("Wrong felts. Expected %v, got %v", expected, felts)
+	}
+}
+
+func TestMemoryGetFeltRangeWithRelocatable(t *testing.T) {
+	mem := memory.NewMemory()
+	mem.Data[memory.NewRelocatable(0, 0)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1))
+	mem.Data[memory.NewRelocatable(0, 1)] = *memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 0))
+
+	_, err := mem.GetFeltRange(memory.NewRelocatable(0, 0), 2)
+	if !errors.Is(err, memory.ErrExpectedFelt) {
+		t.Errorf("Expected ErrExpectedFelt, got %v", err)
+	}
+}
+
+func TestMemoryGetFeltRangeWithHole(t *testing.T) {
+	mem := memory.NewMemory()
+	mem.Data[memory.NewRelocatable(0, 0)] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1))
+
+	_, err := mem.GetFeltRange(memory.NewRelocatable(0, 0), 2)
+	if !errors.Is(err, memory.ErrUnknownMemory) {
+		t.Errorf("Expected ErrUnknownMemory, got %v", err)
+	}
+}
+
+func TestMemoryInsertIfAbsent(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	addr := memory.NewRelocatable(0, 0)
+
+	err := mem.InsertIfAbsent(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)))
+	if err != nil {
+		t.Errorf("InsertIfAbsent failed with error: %s", err)
+	}
+	value, err := mem.GetFelt(addr)
+	if err != nil || value != lambdaworks.FeltFromUint64(5) {
+		t.Errorf("Expected 5 to be inserted, got %v", value)
+	}
+}
+
+func TestMemoryInsertIfAbsentSameValue(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	addr := memory.NewRelocatable(0, 0)
+	mem.Insert(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)))
+	mem.SetMaxCells(1)
+
+	err := mem.InsertIfAbsent(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)))
+	if err != nil {
+		t.Errorf("InsertIfAbsent should be a no-op for an equal value, got error: %s", err)
+	}
+}
+
+func TestMemoryInsertIfAbsentSameValueValidates(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	addr := memory.NewRelocatable(0, 0)
+	mem.Insert(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)))
+	ruleErr := errors.New("rule failed")
+	mem.AddValidationRule(0, func(*memory.Memory, memory.Relocatable) ([]memory.Relocatable, error) {
+		return nil, ruleErr
+	})
+
+	err := mem.InsertIfAbsent(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)))
+	if !errors.Is(err, ruleErr) {
+		t.Errorf("InsertIfAbsent should validate a cell that wasn't validated yet, got %v", err)
+	}
+}
+
+func TestMemoryInsertIfAbsentConflict(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	addr := memory.NewRelocatable(0, 0)
+	mem.Insert(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5)))
+
+	err := mem.InsertIfAbsent(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(6)))
+	if !errors.Is(err, memory.ErrMemoryWriteOnce) {
+		t.Errorf("Expected ErrMemoryWriteOnce, got %v", err)
+	}
+}
+
+func TestSortedAddresses(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.AddSegment()
+	mem := &segments.Memory
+	addresses := []memory.Relocatable{
+		memory.NewRelocatable(1, 3),
+		memory.NewRelocatable(0, 10),
+		memory.NewRelocatable(1, 0),
+		memory.NewRelocatable(0, 2),
+	}
+	for _, addr := range addresses {
+		mem.Insert(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(addr.Offset))))
+	}
+	expected := []memory.Relocatable{
+		memory.NewRelocatable(0, 2),
+		memory.NewRelocatable(0, 10),
+		memory.NewRelocatable(1, 0),
+		memory.NewRelocatable(1, 3),
+	}
+	sorted := mem.SortedAddresses()
+	if !reflect.DeepEqual(sorted, expected) {
+		t.Errorf("Wrong address order. Expected %v, got %v", expected, sorted)
+	}
+}
+
+func TestGetSegmentOrderedByOffset(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.AddSegment()
+	mem := &segments.Memory
+	for _, offset := range []uint{7, 1, 4, 0, 9} {
+		mem.Insert(memory.NewRelocatable(0, offset), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(offset))))
+	}
+	mem.Insert(memory.NewRelocatable(1, 3), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(3)))
+	expected := []memory.MaybeRelocatable{
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(4)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(9)),
+	}
+	segment := mem.GetSegment(0)
+	if !reflect.DeepEqual(segment, expected) {
+		t.Errorf("Wrong segment values. Expected %v, got %v", expected, segment)
+	}
+}
+
+func TestSegmentAddressesOnlyReturnsTheSegment(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.AddSegment()
+	segments.AddSegment()
+	mem := &segments.Memory
+	if err := mem.UseDenseBacking(2, 0); err != nil {
+		t.Errorf("UseDenseBacking failed with error: %s", err)
+		return
+	}
+	for _, segmentIndex := range []int{0, 1, 2} {
+		for _, offset := range []uint{5, 0, 3} {
+			mem.Insert(memory.NewRelocatable(segmentIndex, offset), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(offset))))
+		}
+	}
+	for _, segmentIndex := range []int{1, 2} {
+		expected := []memory.Relocatable{
+			memory.NewRelocatable(segmentIndex, 0),
+			memory.NewRelocatable(segmentIndex, 3),
+			memory.NewRelocatable(segmentIndex, 5),
+		}
+		addresses := mem.SegmentAddresses(segmentIndex)
+		if !reflect.DeepEqual(addresses, expected) {
+			t.Errorf("Wrong addresses for segment %d. Expected %v, got %v", segmentIndex, expected, addresses)
+		}
+	}
+}
+
+func TestWatchpointOverwriteAttempt(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	addr := memory.NewRelocatable(0, 0)
+	segments.Memory.Insert(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	called := false
+	segments.Memory.AddWatchpoint(addr, func(old *memory.MaybeRelocatable, new *memory.MaybeRelocatable) {
+		called = true
+	})
+	err := segments.Memory.Insert(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2)))
+	if !errors.Is(err, memory.ErrMemoryWriteOnce) {
+		t.Errorf("Overwriting the cell should fail with ErrMemoryWriteOnce, got %v", err)
+	}
+	if called {
+		t.Errorf("The watchpoint should not be called when the value isn't stored")
+	}
+}
+
+func TestWatchpointNotCopiedByClone(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	addr := memory.NewRelocatable(0, 0)
+	called := false
+	segments.Memory.AddWatchpoint(addr, func(old *memory.MaybeRelocatable, new *memory.MaybeRelocatable) {
+		called = true
+	})
+	clone := segments.Memory.Clone()
+	clone.Insert(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	if called {
+		t.Errorf("Writing into the clone should not call the original's watchpoint")
+	}
+}
+
+func TestDenseBackingInsertAndGet(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.AddSegment()
+	mem := &segments.Memory
+	// Cells inserted before switching the backing are kept
+	mem.Insert(memory.NewRelocatable(0, 1), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	err := mem.UseDenseBacking(0, 4)
+	if err != nil {
+		t.Errorf("UseDenseBacking failed with error: %s", err)
+		return
+	}
+	mem.Insert(memory.NewRelocatable(0, 5), memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 0)))
+	mem.Insert(memory.NewRelocatable(1, 2), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(3)))
+
+	value, err := mem.GetFelt(memory.NewRelocatable(0, 1))
+	if err != nil || value != lambdaworks.FeltFromUint64(1) {
+		t.Errorf("Expected the moved cell to hold 1, got %v, %v", value, err)
+	}
+	ptr, err := mem.GetRelocatable(memory.NewRelocatable(0, 5))
+	if err != nil || ptr != memory.NewRelocatable(1, 0) {
+		t.Errorf("Expected the dense cell to hold (1, 0), got %v, %v", ptr, err)
+	}
+	// Holes of dense segments are still unknown memory
+	_, err = mem.Get(memory.NewRelocatable(0, 3))
+	if !errors.Is(err, memory.ErrUnknownMemory) {
+		t.Errorf("Expected ErrUnknownMemory for a hole, got %v", err)
+	}
+	err = mem.Insert(memory.NewRelocatable(0, 5), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2)))
+	if !errors.Is(err, memory.ErrMemoryWriteOnce) {
+		t.Errorf("Expected ErrMemoryWriteOnce, got %v", err)
+	}
+	if len(mem.Data) != 1 {
+		t.Errorf("Only the cells of map backed segments should be in Data, found %d", len(mem.Data))
+	}
+	expectedAddresses := []memory.Relocatable{memory.NewRelocatable(0, 1), memory.NewRelocatable(0, 5), memory.NewRelocatable(1, 2)}
+	if addresses := mem.SortedAddresses(); !reflect.DeepEqual(addresses, expectedAddresses) {
+		t.Errorf("Wrong addresses. Expected %v, got %v", expectedAddresses, addresses)
+	}
+	expectedSizes := map[uint]uint{0: 6, 1: 3}
+	if sizes := segments.ComputeEffectiveSizes(); !reflect.DeepEqual(sizes, expectedSizes) {
+		t.Errorf("Wrong segment sizes. Expected %v, got %v", expectedSizes, sizes)
+	}
+}
+
+func TestDenseBackingMatchesMapBacking(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	dense := segments.Memory.Clone()
+	dense.UseDenseBacking(0, 0)
+	for _, offset := range []uint{3, 0, 2} {
+		value := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(offset)))
+		segments.Memory.Insert(memory.NewRelocatable(0, offset), value)
+		dense.Insert(memory.NewRelocatable(0, offset), value)
+	}
+	if equal, diff := segments.Memory.Equals(dense); !equal {
+		t.Errorf("Memories should be equal, differ at %v", diff)
+	}
+	if !reflect.DeepEqual(segments.Memory.GetSegment(0), dense.GetSegment(0)) {
+		t.Errorf("Segments should be equal")
+	}
+	// Clones don't share the dense backing
+	clone := dense.Clone()
+	clone.Insert(memory.NewRelocatable(0, 1), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	if _, err := dense.Get(memory.NewRelocatable(0, 1)); err == nil {
+		t.Errorf("Inserting into the clone should not modify the original")
+	}
+}
+
+func TestDenseBackingSparseOffsetsUseMap(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	mem := &segments.Memory
+	mem.UseDenseBacking(0, 0)
+	// Storing this cell densely would allocate every offset up to it
+	farAddr := memory.NewRelocatable(0, 1<<40)
+	mem.Insert(memory.NewRelocatable(0, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	err := mem.Insert(farAddr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2)))
+	if err != nil {
+		t.Errorf("Insert failed with error: %s", err)
+		return
+	}
+	if len(mem.Data) != 1 {
+		t.Errorf("The sparse cell should be stored in Data, found %d cells", len(mem.Data))
+	}
+	value, err := mem.GetFelt(farAddr)
+	if err != nil || value != lambdaworks.FeltFromUint64(2) {
+		t.Errorf("Expected the sparse cell to hold 2, got %v, %v", value, err)
+	}
+	expectedAddresses := []memory.Relocatable{memory.NewRelocatable(0, 0), farAddr}
+	if addresses := mem.SegmentAddresses(0); !reflect.DeepEqual(addresses, expectedAddresses) {
+		t.Errorf("Wrong addresses. Expected %v, got %v", expectedAddresses, addresses)
+	}
+}
+
+func TestDenseBackingGrowsOverSparseCell(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	mem := &segments.Memory
+	mem.UseDenseBacking(0, 0)
+	// Written before the segment reaches it, so it is stored in Data
+	mem.Insert(memory.NewRelocatable(0, 5000), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(5000)))
+	for i := uint(0); i <= 5000; i++ {
+		err := mem.Insert(memory.NewRelocatable(0, i), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(i))))
+		if err != nil {
+			t.Errorf("Insert failed with error: %s", err)
+			return
+		}
+	}
+	// The sparse cell isn't duplicated once the dense segment grows past it
+	if cells := len(mem.GetSegment(0)); cells != 5001 {
+		t.Errorf("Expected 5001 cells, got %d", cells)
+	}
+	if continuous, hole := mem.IsSegmentContinuous(0); !continuous {
+		t.Errorf("Segment should be continuous, found hole at %v", hole)
+	}
+}
+
+func TestDenseBackingMissingSegment(t *testing.T) {
+	mem := memory.NewMemory()
+	err := mem.UseDenseBacking(0, 0)
+	if !errors.Is(err, memory.ErrSegmentNotFound) {
+		t.Errorf("Expected ErrSegmentNotFound, got %v", err)
+	}
+}
+
+func benchmarkSegmentFootprint(b *testing.B, dense bool) {
+	size := uint(1000000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		segments := memory.NewMemorySegmentManager()
+		segments.AddSegment()
+		if dense {
+			segments.Memory.UseDenseBacking(0, 0)
+		}
+		for j := uint(0); j < size; j++ {
+			segments.Memory.Insert(memory.NewRelocatable(0, j), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(j))))
+		}
+		if i == 0 {
+			runtime.GC()
+			var stats runtime.MemStats
+			runtime.ReadMemStats(&stats)
+			b.ReportMetric(float64(stats.HeapAlloc), "heap-bytes")
+			runtime.KeepAlive(segments)
+		}
+	}
+}
+
+func BenchmarkSegmentFootprintMapBacking(b *testing.B) {
+	benchmarkSegmentFootprint(b, false)
+}
+
+func BenchmarkSegmentFootprintDenseBacking(b *testing.B) {
+	benchmarkSegmentFootprint(b, true)
+}
+
+func TestGetIntegerInRange(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	addr := memory.NewRelocatable(0, 0)
+	segments.Memory.Insert(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(255)))
+	value, err := segments.Memory.GetInteger(addr, 8)
+	if err != nil || value != lambdaworks.FeltFromUint64(255) {
+		t.Errorf("Expected 255, got %v, %v", value, err)
+	}
+}
+
+func TestGetIntegerOutOfRange(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	addr := memory.NewRelocatable(0, 0)
+	segments.Memory.Insert(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(256)))
+	_, err := segments.Memory.GetInteger(addr, 8)
+	if !errors.Is(err, memory.ErrValueOutOfRange) {
+		t.Errorf("Expected ErrValueOutOfRange, got %v", err)
+	}
+}
+
+func TestGetIntegerRelocatable(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	addr := memory.NewRelocatable(0, 0)
+	segments.Memory.Insert(addr, memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(0, 1)))
+	_, err := segments.Memory.GetInteger(addr, 8)
+	if !errors.Is(err, memory.ErrExpectedFelt) {
+		t.Errorf("Expected ErrExpectedFelt, got %v", err)
+	}
+}
+
+func TestInsufficientAllocatedCellsIsSentinel(t *testing.T) {
+	variants := []memory.InsufficientAllocatedCells{
+		{Kind: memory.INSUFFICIENT_CELLS_BUILTIN, Builtin: builtins.RANGE_CHECK_BUILTIN_NAME, Used: 10, Allocated: 8},
+		{Kind: memory.INSUFFICIENT_CELLS_MIN_STEP, Builtin: builtins.PEDERSEN_BUILTIN_NAME, Allocated: 256},
+		{Kind: memory.INSUFFICIENT_CELLS_MEMORY_UNITS, Used: 5, Allocated: 4},
+		{Kind: memory.INSUFFICIENT_CELLS_DILUTED_UNITS, Used: 65536, Allocated: 100},
+		{Kind: memory.INSUFFICIENT_CELLS_RANGE_CHECK_UNITS, Used: 30, Allocated: 20},
+	}
+	for _, variant := range variants {
+		var err error = variant
+		if !errors.Is(err, memory.ErrInsufficientAllocatedCells) {
+			t.Errorf("Expected %s error to be ErrInsufficientAllocatedCells", variant.Kind)
+		}
+		var target memory.InsufficientAllocatedCells
+		if !errors.As(err, &target) || target != variant {
+			t.Errorf("Expected %s error to be retrievable with errors.As", variant.Kind)
+		}
+	}
+}
+
+func TestIsSegmentContinuousWithHole(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	for _, offset := range []uint{0, 1, 3, 5} {
+		mem.Insert(memory.NewRelocatable(1, offset), memory.NewMaybeRelocatableFelt(lambdaworks.FeltOne()))
+	}
+
+	continuous, hole := mem.IsSegmentContinuous(1)
+	if continuous {
+		t.Errorf("Segment with holes reported as continuous")
+		return
+	}
+	expectedHole := memory.NewRelocatable(1, 2)
+	if hole == nil || *hole != expectedHole {
+		t.Errorf("Wrong first hole. Expected %+v, got %+v", expectedHole, hole)
+	}
+}
+
+func TestIsSegmentContinuousWithoutHoles(t *testing.T) {
+	mem_manager := memory.NewMemorySegmentManager()
+	mem_manager.AddSegment()
+	mem_manager.AddSegment()
+	mem := &mem_manager.Memory
+	for offset := uint(0); offset < 4; offset++ {
+		mem.Insert(memory.NewRelocatable(0, offset), memory.NewMaybeRelocatableFelt(lambdaworks.FeltOne()))
+	}
+
+	continuous, hole := mem.IsSegmentContinuous(0)
+	if !continuous || hole != nil {
+		t.Errorf("Continuous segment reported a hole at %+v", hole)
+	}
+	continuous, hole = mem.IsSegmentContinuous(1)
+	if !continuous || hole != nil {
+		t.Errorf("Empty segment reported a hole at %+v", hole)
+	}
+}
+
+func TestGetFeltUnchecked(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	feltAddr := memory.NewRelocatable(0, 0)
+	relocatableAddr := memory.NewRelocatable(0, 1)
+	segments.Memory.Insert(feltAddr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)))
+	segments.Memory.Insert(relocatableAddr, memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(0, 0)))
+
+	value, ok := segments.Memory.GetFeltUnchecked(feltAddr)
+	if !ok || value != lambdaworks.FeltFromUint64(7) {
+		t.Errorf("Expected 7, got %v, %t", value, ok)
+	}
+	if _, ok := segments.Memory.GetFeltUnchecked(relocatableAddr); ok {
+		t.Errorf("GetFeltUnchecked should fail on a relocatable value")
+	}
+	if _, ok := segments.Memory.GetFeltUnchecked(memory.NewRelocatable(0, 2)); ok {
+		t.Errorf("GetFeltUnchecked should fail on an empty cell")
+	}
+	if _, ok := segments.Memory.GetFeltUnchecked(memory.NewRelocatable(-1, 0)); ok {
+		t.Errorf("GetFeltUnchecked should fail on a temporary address")
+	}
+}
+
+func benchmarkReadFelts(b *testing.B, unchecked bool) {
+	size := uint(100000)
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	for j := uint(0); j < size; j++ {
+		segments.Memory.Insert(memory.NewRelocatable(0, j), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(j))))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Sums the cells like a hint iterating over a large range would
+		sum := lambdaworks.FeltZero()
+		for j := uint(0); j < size; j++ {
+			addr := memory.NewRelocatable(0, j)
+			if unchecked {
+				value, ok := segments.Memory.GetFeltUnchecked(addr)
+				if !ok {
+					b.Fatalf("Missing felt at %+v", addr)
+				}
+				sum = sum.Add(value)
+			} else {
+				value, err := segments.Memory.GetFelt(addr)
+				if err != nil {
+					b.Fatal(err)
+				}
+				sum = sum.Add(value)
+			}
+		}
+	}
+}
+
+func BenchmarkReadFeltsChecked(b *testing.B) {
+	benchmarkReadFelts(b, false)
+}
+
+func BenchmarkReadFeltsUnchecked(b *testing.B) {
+	benchmarkReadFelts(b, true)
+}
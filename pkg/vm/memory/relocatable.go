@@ -7,6 +7,10 @@ import (
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 )
 
+var ErrSegmentNotRelocated = errors.New("Segment missing from the relocation table")
+
+var ErrRelocatableNotFelt = errors.New("Relocatable value can't be used as a felt without a relocation table")
+
 // Relocatable in the Cairo VM represents an address
 // in some memory segment. When the VM finishes running,
 // these values are replaced by real memory addresses,
@@ -147,6 +151,32 @@ func (m *MaybeRelocatable) RelocateValue(relocationTable *[]uint) (lambdaworks.F
 	return lambdaworks.FeltZero(), errors.New(fmt.Sprintf("Unexpected type %T", m.inner))
 }
 
+// Turns a single value into a Felt252, resolving a relocatable to its flat address according to the
+// relocation table and returning a felt unchanged.
+// Fails with ErrSegmentNotRelocated if the relocatable's segment is missing from the relocation table
+func RelocateValue(value MaybeRelocatable, relocationTable []uint) (lambdaworks.Felt, error) {
+	relocatable, ok := value.GetRelocatable()
+	if ok && (relocatable.SegmentIndex < 0 || relocatable.SegmentIndex >= len(relocationTable)) {
+		return lambdaworks.FeltZero(), fmt.Errorf("%w: segment %d", ErrSegmentNotRelocated, relocatable.SegmentIndex)
+	}
+	return value.RelocateValue(&relocationTable)
+}
+
+// Returns the inner value if m is a Felt.
+// Fails with ErrRelocatableNotFelt if m is a Relocatable, use ToFeltWithRelocation to cast it to its relocated address
+func (m *MaybeRelocatable) ToFelt() (lambdaworks.Felt, error) {
+	felt, ok := m.GetFelt()
+	if !ok {
+		return lambdaworks.FeltZero(), fmt.Errorf("%w: %s", ErrRelocatableNotFelt, m.ToString())
+	}
+	return felt, nil
+}
+
+// Returns the inner value if m is a Felt, or its relocated address according to the relocation table if m is a Relocatable
+func (m *MaybeRelocatable) ToFeltWithRelocation(relocationTable []uint) (lambdaworks.Felt, error) {
+	return RelocateValue(*m, relocationTable)
+}
+
 func (m *MaybeRelocatable) IsEqual(m1 *MaybeRelocatable) bool {
 	a, a_type := m.GetFelt()
 	b, b_type := m1.GetFelt()
@@ -243,6 +273,15 @@ func (m *MaybeRelocatable) ToString() string {
 	return felt.ToSignedFeltString()
 }
 
+// Implements fmt.Stringer, printing felts as their decimal value and relocatables as {segment:offset}
+func (m MaybeRelocatable) String() string {
+	if rel, is_rel := m.GetRelocatable(); is_rel {
+		return rel.ToString()
+	}
+	felt, _ := m.GetFelt()
+	return felt.String()
+}
+
 func (r *Relocatable) ToString() string {
 	return fmt.Sprintf("{%d:%d}", r.SegmentIndex, r.Offset)
 }
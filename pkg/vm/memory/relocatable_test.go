@@ -1,6 +1,8 @@
 package memory_test
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -257,3 +259,72 @@ func TestRelocatableAddIntNegative(t *testing.T) {
 		t.Errorf("got wrong value from Relocatable.AddInt, expected: %v, got: %v", expected, res)
 	}
 }
+
+func TestMaybeRelocatableStringFelt(t *testing.T) {
+	value := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(26))
+	if result := fmt.Sprintf("%v", value); result != "26" {
+		t.Errorf("Wrong string for felt value. Expected 26, got %s", result)
+	}
+}
+
+func TestMaybeRelocatableStringRelocatable(t *testing.T) {
+	value := *memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(2, 5))
+	if result := fmt.Sprintf("%v", value); result != "{2:5}" {
+		t.Errorf("Wrong string for relocatable value. Expected {2:5}, got %s", result)
+	}
+}
+
+func TestRelocateValueRelocatable(t *testing.T) {
+	relocationTable := []uint{1, 5, 12}
+	value := memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(2, 3))
+	relocated, err := memory.RelocateValue(*value, relocationTable)
+	if err != nil {
+		t.Errorf("RelocateValue failed with error: %s", err)
+		return
+	}
+	if relocated != lambdaworks.FeltFromUint64(15) {
+		t.Errorf("Wrong relocated value. Expected 15, got %s", relocated.ToSignedFeltString())
+	}
+}
+
+func TestRelocateValueFelt(t *testing.T) {
+	relocationTable := []uint{1, 5, 12}
+	value := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7))
+	relocated, err := memory.RelocateValue(*value, relocationTable)
+	if err != nil || relocated != lambdaworks.FeltFromUint64(7) {
+		t.Errorf("Expected the felt to be returned unchanged, got %v, %v", relocated, err)
+	}
+}
+
+func TestRelocateValueMissingSegment(t *testing.T) {
+	relocationTable := []uint{1, 5}
+	value := memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(2, 3))
+	_, err := memory.RelocateValue(*value, relocationTable)
+	if !errors.Is(err, memory.ErrSegmentNotRelocated) {
+		t.Errorf("Expected ErrSegmentNotRelocated, got %v", err)
+	}
+}
+
+func TestToFeltFelt(t *testing.T) {
+	value := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7))
+	felt, err := value.ToFelt()
+	if err != nil || felt != lambdaworks.FeltFromUint64(7) {
+		t.Errorf("Expected the inner felt to be returned, got %v, %v", felt, err)
+	}
+}
+
+func TestToFeltRelocatable(t *testing.T) {
+	value := memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(2, 3))
+	_, err := value.ToFelt()
+	if !errors.Is(err, memory.ErrRelocatableNotFelt) {
+		t.Errorf("Expected ErrRelocatableNotFelt, got %v", err)
+	}
+}
+
+func TestToFeltWithRelocationRelocatable(t *testing.T) {
+	value := memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(2, 3))
+	felt, err := value.ToFeltWithRelocation([]uint{1, 5, 12})
+	if err != nil || felt != lambdaworks.FeltFromUint64(15) {
+		t.Errorf("Expected the relocated address 15, got %v, %v", felt, err)
+	}
+}
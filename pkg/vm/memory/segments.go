@@ -2,8 +2,13 @@ package memory
 
 import (
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/pkg/errors"
 )
 
+var ErrTooManySegments = errors.New("Maximum amount of memory segments reached")
+var ErrSegmentNotFound = errors.New("Memory segment not found")
+var ErrMalformedPublicMemory = errors.New("Public memory references a segment missing from the relocation table")
+
 // MemorySegmentManager manages the list of memory segments.
 // Also holds metadata useful for the relocation process of
 // the memory at the end of the VM run.
@@ -15,36 +20,164 @@ type MemorySegmentManager struct {
 	// The thing is, that second uint is ALWAYS zero. Every single single time someone instantiates
 	// some public memory, that second value is zero. I just removed it.
 	PublicMemoryOffsets map[uint][]uint
+	// Maximum amount of segments that can be added, zero means unlimited
+	MaxSegments uint
+	// Segments known not to grow anymore, their used sizes are not recomputed
+	frozenSegments map[uint]bool
+	// Whether the used sizes were scanned from the memory, as every segment could be frozen or empty
+	sizesScanned bool
 }
 
 func NewMemorySegmentManager() MemorySegmentManager {
 	memory := NewMemory()
-	return MemorySegmentManager{make(map[uint]uint), make(map[uint]uint), *memory, make(map[uint][]uint)}
+	return MemorySegmentManager{
+		SegmentUsedSizes:    make(map[uint]uint),
+		SegmentSizes:        make(map[uint]uint),
+		Memory:              *memory,
+		PublicMemoryOffsets: make(map[uint][]uint),
+		frozenSegments:      make(map[uint]bool),
+	}
+}
+
+// Returns a deep copy of the segment manager, including its memory
+func (m *MemorySegmentManager) Clone() MemorySegmentManager {
+	segmentUsedSizes := make(map[uint]uint, len(m.SegmentUsedSizes))
+	for index, size := range m.SegmentUsedSizes {
+		segmentUsedSizes[index] = size
+	}
+	segmentSizes := make(map[uint]uint, len(m.SegmentSizes))
+	for index, size := range m.SegmentSizes {
+		segmentSizes[index] = size
+	}
+	publicMemoryOffsets := make(map[uint][]uint, len(m.PublicMemoryOffsets))
+	for index, offsets := range m.PublicMemoryOffsets {
+		publicMemoryOffsets[index] = append([]uint(nil), offsets...)
+	}
+	frozenSegments := make(map[uint]bool, len(m.frozenSegments))
+	for index, frozen := range m.frozenSegments {
+		frozenSegments[index] = frozen
+	}
+	return MemorySegmentManager{
+		SegmentUsedSizes:    segmentUsedSizes,
+		SegmentSizes:        segmentSizes,
+		Memory:              *m.Memory.Clone(),
+		PublicMemoryOffsets: publicMemoryOffsets,
+		MaxSegments:         m.MaxSegments,
+		frozenSegments:      frozenSegments,
+		sizesScanned:        m.sizesScanned,
+	}
 }
 
 // Adds a memory segment and returns the first address of the new segment
-func (m *MemorySegmentManager) AddSegment() Relocatable {
+// Fails with ErrTooManySegments if MaxSegments is set and has already been reached
+func (m *MemorySegmentManager) AddSegment() (Relocatable, error) {
+	if m.MaxSegments != 0 && m.Memory.numSegments >= m.MaxSegments {
+		return Relocatable{}, ErrTooManySegments
+	}
 	ptr := Relocatable{int(m.Memory.numSegments), 0}
 	m.Memory.numSegments += 1
-	return ptr
+	return ptr, nil
+}
+
+// Adds a memory segment like AddSegment, storing it in a dense backing with room for capacity cells so that
+// filling a segment of a known size doesn't repeatedly grow the underlying storage
+func (m *MemorySegmentManager) AddSegmentWithCapacity(capacity uint) (Relocatable, error) {
+	ptr, err := m.AddSegment()
+	if err != nil {
+		return Relocatable{}, err
+	}
+	if err := m.Memory.UseDenseBacking(uint(ptr.SegmentIndex), capacity); err != nil {
+		return Relocatable{}, err
+	}
+	return ptr, nil
 }
 
 // Calculates the size of each memory segment.
+// Sizes are only computed once, further calls return the previously computed sizes.
+// Frozen segments keep the size they were frozen with and are not rescanned
 func (m *MemorySegmentManager) ComputeEffectiveSizes() map[uint]uint {
-	if len(m.SegmentUsedSizes) == 0 {
-		for ptr := range m.Memory.Data {
-			segmentIndex := uint(ptr.SegmentIndex)
-			segmentMaxSize := m.SegmentUsedSizes[segmentIndex]
-			segmentSize := ptr.Offset + 1
-			if segmentSize > segmentMaxSize {
-				m.SegmentUsedSizes[segmentIndex] = segmentSize
-			}
+	if !m.effectiveSizesComputed() {
+		m.scanEffectiveSizes()
+	}
+
+	return m.SegmentUsedSizes
+}
+
+// Returns whether the sizes were scanned, or the size of some segment that isn't frozen is otherwise known.
+// Frozen segments are given a size when frozen, so they don't tell whether the sizes were computed
+func (m *MemorySegmentManager) effectiveSizesComputed() bool {
+	if m.sizesScanned {
+		return true
+	}
+	for segmentIndex := range m.SegmentUsedSizes {
+		if !m.frozenSegments[segmentIndex] {
+			return true
 		}
 	}
+	return false
+}
+
+// Recomputes the size of each memory segment that is not frozen, even if sizes were already computed.
+// Frozen segments keep the size they were frozen with. Every cell stored in the Data map is still visited,
+// including the ones of frozen segments, while dense segments are sized from their length without visiting their cells
+func (m *MemorySegmentManager) UpdateEffectiveSizes() map[uint]uint {
+	for segmentIndex := range m.SegmentUsedSizes {
+		if !m.frozenSegments[segmentIndex] {
+			delete(m.SegmentUsedSizes, segmentIndex)
+		}
+	}
+	m.scanEffectiveSizes()
 
 	return m.SegmentUsedSizes
 }
 
+func (m *MemorySegmentManager) scanEffectiveSizes() {
+	m.sizesScanned = true
+	for ptr := range m.Memory.Data {
+		segmentIndex := uint(ptr.SegmentIndex)
+		if m.frozenSegments[segmentIndex] {
+			continue
+		}
+		segmentMaxSize := m.SegmentUsedSizes[segmentIndex]
+		segmentSize := ptr.Offset + 1
+		if segmentSize > segmentMaxSize {
+			m.SegmentUsedSizes[segmentIndex] = segmentSize
+		}
+	}
+	// Dense segments only grow up to their last cell, so their size is their length
+	for segmentIndex, segment := range m.Memory.denseSegments {
+		if m.frozenSegments[segmentIndex] || len(segment.values) == 0 {
+			continue
+		}
+		if segmentSize := uint(len(segment.values)); segmentSize > m.SegmentUsedSizes[segmentIndex] {
+			m.SegmentUsedSizes[segmentIndex] = segmentSize
+		}
+	}
+}
+
+// Marks a segment as frozen with the given used size.
+// The segment is assumed not to grow anymore, so its size won't be recomputed by
+// ComputeEffectiveSizes or UpdateEffectiveSizes
+func (m *MemorySegmentManager) FreezeSegment(segmentIndex uint, usedSize uint) {
+	if m.frozenSegments == nil {
+		m.frozenSegments = make(map[uint]bool)
+	}
+	m.frozenSegments[segmentIndex] = true
+	m.SegmentUsedSizes[segmentIndex] = usedSize
+}
+
+// Returns true if the segment was frozen via FreezeSegment
+func (m *MemorySegmentManager) IsSegmentFrozen(segmentIndex uint) bool {
+	return m.frozenSegments[segmentIndex]
+}
+
+// Overrides the used size of a segment, regardless of the cells inserted into it.
+// Only meant for tests building artificial states: the segment is frozen with the given size,
+// so the override survives further calls to ComputeEffectiveSizes and UpdateEffectiveSizes
+func (m *MemorySegmentManager) SetSegmentUsedSizeForTest(index uint, size uint) {
+	m.FreezeSegment(index, size)
+}
+
 // Returns a vector containing the first relocated address of each memory segment
 func (m *MemorySegmentManager) RelocateSegments() ([]uint, error) {
 	first_addr := uint(1)
@@ -106,10 +239,15 @@ func (m *MemorySegmentManager) LoadData(ptr Relocatable, data *[]MaybeRelocatabl
 	return ptr, nil
 }
 
+// Returns the used size of the segment, as computed by ComputeEffectiveSizes.
+// Segments whose size wasn't computed yet are considered empty, while segments that
+// weren't added to the memory fail with ErrSegmentNotFound
 func (m *MemorySegmentManager) GetSegmentUsedSize(segmentIdx uint) (uint, error) {
 	size, ok := m.SegmentUsedSizes[segmentIdx]
 	if !ok {
-		// return 0, errors.Errorf("segment %d used size not found", segmentIdx)
+		if segmentIdx >= m.Memory.NumSegments() {
+			return 0, errors.Wrapf(ErrSegmentNotFound, "segment %d", segmentIdx)
+		}
 		return 0, nil
 	}
 	return size, nil
@@ -124,20 +262,33 @@ func (m *MemorySegmentManager) GetSegmentSize(index uint) (uint, error) {
 	return size, nil
 }
 
+// Returns the size the segment was finalized with via Finalize, and true if such a size was set.
+// Unlike GetSegmentSize, it doesn't fall back to the segment's used size
+func (m *MemorySegmentManager) GetFinalizedSize(index uint) (*uint, bool) {
+	size, ok := m.SegmentSizes[index]
+	if !ok {
+		return nil, false
+	}
+	return &size, true
+}
+
 // Go through each segment, calculate its size (counting holes), then count memory accesses. Substract the two and you
 // get the holes for that segment. Sum each value and that's it.
-// IMPORTANT: Builtin Segments DO NOT HAVE HOLES, so we don't need to count them.
+// IMPORTANT: Builtin Segments DO NOT HAVE HOLES, so we don't need to count them. They are identified by their
+// segment index, as given by builtinSegments, regardless of where they are placed among the other segments.
 // This function assumes you have already called `ComputeEffectiveSizes`, if you haven't, you'll get the wrong
 // result
-func (m *MemorySegmentManager) GetMemoryHoles(builtinCount uint) (uint, error) {
+func (m *MemorySegmentManager) GetMemoryHoles(builtinSegments []uint) (uint, error) {
 	var memoryHoles uint
 	accessedCellsBySegment := make(map[uint]uint)
 
-	var builtinSegmentsStart uint = 1
-	var builtinSegmentsEnd uint = builtinSegmentsStart + builtinCount
+	isBuiltinSegment := make(map[uint]bool, len(builtinSegments))
+	for _, segmentIndex := range builtinSegments {
+		isBuiltinSegment[segmentIndex] = true
+	}
 
 	for address := range m.Memory.AccessedAddresses {
-		if uint(address.SegmentIndex) > builtinSegmentsStart && uint(address.SegmentIndex) <= builtinSegmentsEnd {
+		if address.SegmentIndex < 0 || isBuiltinSegment[uint(address.SegmentIndex)] {
 			continue
 		}
 
@@ -145,7 +296,7 @@ func (m *MemorySegmentManager) GetMemoryHoles(builtinCount uint) (uint, error) {
 	}
 
 	for segmentIndex := range m.SegmentUsedSizes {
-		if segmentIndex > builtinSegmentsStart && segmentIndex <= builtinSegmentsEnd {
+		if isBuiltinSegment[segmentIndex] {
 			continue
 		}
 
@@ -172,3 +323,24 @@ func (m *MemorySegmentManager) Finalize(size *uint, segmentIndex uint, publicMem
 		m.PublicMemoryOffsets[segmentIndex] = emptyList
 	}
 }
+
+// Returns the relocated (flat) addresses of the public memory of every segment, ordered by segment index.
+// Each address is obtained by adding the public memory offset to the segment's first relocated address,
+// as given by the relocation table returned by RelocateSegments
+func (m *MemorySegmentManager) GetPublicMemoryAddresses(relocationTable *[]uint) ([]uint, error) {
+	addresses := make([]uint, 0)
+	for segmentIndex := uint(0); segmentIndex < m.Memory.numSegments; segmentIndex++ {
+		offsets, ok := m.PublicMemoryOffsets[segmentIndex]
+		if !ok || len(offsets) == 0 {
+			continue
+		}
+		if segmentIndex >= uint(len(*relocationTable)) {
+			return nil, ErrMalformedPublicMemory
+		}
+		segmentStart := (*relocationTable)[segmentIndex]
+		for _, offset := range offsets {
+			addresses = append(addresses, segmentStart+offset)
+		}
+	}
+	return addresses, nil
+}
@@ -1,6 +1,8 @@
 package memory_test
 
 import (
+	"bytes"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -128,6 +130,21 @@ func TestGetSegmentUsedSizeBeforeComputingUsed(t *testing.T) {
 	}
 }
 
+func TestGetSegmentUsedSizeMissingSegment(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+
+	size, err := segments.GetSegmentUsedSize(0)
+	if err != nil || size != 0 {
+		t.Errorf("Existing segment without computed size should have size 0, got %d, %v", size, err)
+		return
+	}
+	_, err = segments.GetSegmentUsedSize(1)
+	if !errors.Is(err, memory.ErrSegmentNotFound) {
+		t.Errorf("Expected ErrSegmentNotFound, got %v", err)
+	}
+}
+
 func TestRelocateOneSegment(t *testing.T) {
 	segments := memory.NewMemorySegmentManager()
 	segments.AddSegment()
@@ -223,6 +240,41 @@ func TestRelocateMemory(t *testing.T) {
 	}
 }
 
+func TestRelocateMemoryIsDeterministic(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	for i := 0; i < 3; i++ {
+		segments.AddSegment()
+	}
+	for i := uint(0); i < 20; i++ {
+		segments.Memory.Insert(memory.NewRelocatable(0, i), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(i))))
+		segments.Memory.Insert(memory.NewRelocatable(1, 2*i), memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(2, i)))
+		segments.Memory.Insert(memory.NewRelocatable(2, i), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(100+i))))
+	}
+
+	var encodings [2]bytes.Buffer
+	for i := range encodings {
+		segments.ComputeEffectiveSizes()
+		relocationTable, err := segments.RelocateSegments()
+		if err != nil {
+			t.Errorf("Could not create relocation table: %s", err)
+			return
+		}
+		relocatedMemory, err := segments.RelocateMemory(&relocationTable)
+		if err != nil {
+			t.Errorf("RelocateMemory failed with error: %s", err)
+			return
+		}
+		err = vm.WriteEncodedMemory(relocatedMemory, &encodings[i])
+		if err != nil {
+			t.Errorf("WriteEncodedMemory failed with error: %s", err)
+			return
+		}
+	}
+	if !bytes.Equal(encodings[0].Bytes(), encodings[1].Bytes()) {
+		t.Errorf("Relocating the same memory twice produced different outputs")
+	}
+}
+
 func TestGetMemoryHoles(t *testing.T) {
 	manager := memory.NewMemorySegmentManager()
 	manager.AddSegment()
@@ -240,7 +292,7 @@ func TestGetMemoryHoles(t *testing.T) {
 		manager.Memory.MarkAsAccessed(address)
 	}
 	manager.ComputeEffectiveSizes()
-	result, err := manager.GetMemoryHoles(0)
+	result, err := manager.GetMemoryHoles(nil)
 
 	if err != nil {
 		t.Errorf("Get Memory Holes returned error %s", err)
@@ -250,3 +302,282 @@ func TestGetMemoryHoles(t *testing.T) {
 		t.Errorf("Get Memory Holes Returned the wrong value. Expected: 2, got %d", result)
 	}
 }
+
+func TestGetMemoryHolesBuiltinSegmentBeforeUserSegment(t *testing.T) {
+	manager := memory.NewMemorySegmentManager()
+	// Segment 0 belongs to a builtin, segment 1 is a user segment
+	manager.AddSegment()
+	manager.AddSegment()
+
+	var i uint
+	for i = 0; i < 10; i++ {
+		// The builtin segment has holes at 2 and 3, which must not be counted
+		if i != 2 && i != 3 {
+			builtinAddress := memory.NewRelocatable(0, i)
+			manager.Memory.Insert(builtinAddress, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0)))
+		}
+
+		userAddress := memory.NewRelocatable(1, i)
+		manager.Memory.Insert(userAddress, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0)))
+		// Skip marking address 7 of the user segment as accessed
+		if i != 7 {
+			manager.Memory.MarkAsAccessed(userAddress)
+		}
+	}
+	manager.ComputeEffectiveSizes()
+	result, err := manager.GetMemoryHoles([]uint{0})
+
+	if err != nil {
+		t.Errorf("Get Memory Holes returned error %s", err)
+	}
+
+	if result != 1 {
+		t.Errorf("Get Memory Holes Returned the wrong value. Expected: 1, got %d", result)
+	}
+}
+
+func TestAddSegmentMaxSegmentsReached(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.MaxSegments = 2
+	for i := 0; i < 2; i++ {
+		_, err := segments.AddSegment()
+		if err != nil {
+			t.Errorf("AddSegment error in test: %s", err)
+		}
+	}
+	_, err := segments.AddSegment()
+	if err != memory.ErrTooManySegments {
+		t.Errorf("AddSegment should have failed with ErrTooManySegments, got: %v", err)
+	}
+	if segments.Memory.NumSegments() != 2 {
+		t.Errorf("Wrong amount of segments: expected 2, got %d", segments.Memory.NumSegments())
+	}
+}
+
+func TestAddSegmentNoMaxSegments(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	for i := 0; i < 100; i++ {
+		ptr, err := segments.AddSegment()
+		if err != nil {
+			t.Errorf("AddSegment error in test: %s", err)
+		}
+		if ptr != memory.NewRelocatable(i, 0) {
+			t.Errorf("Wrong segment base: expected (%d, 0), got %+v", i, ptr)
+		}
+	}
+}
+
+func TestComputeEffectiveSizesSkipsFrozenSegment(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.AddSegment()
+	segments.Memory.Insert(memory.NewRelocatable(0, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	segments.Memory.Insert(memory.NewRelocatable(0, 1), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	segments.Memory.Insert(memory.NewRelocatable(1, 4), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	segments.FreezeSegment(0, 2)
+	// Cells added to a frozen segment are not taken into account
+	segments.Memory.Insert(memory.NewRelocatable(0, 5), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+
+	segments.ComputeEffectiveSizes()
+
+	expectedSizes := map[uint]uint{0: 2, 1: 5}
+	if !reflect.DeepEqual(expectedSizes, segments.SegmentUsedSizes) {
+		t.Errorf("Segment sizes are not the same. Expected %v, got %v", expectedSizes, segments.SegmentUsedSizes)
+	}
+	if !segments.IsSegmentFrozen(0) || segments.IsSegmentFrozen(1) {
+		t.Errorf("Only segment 0 should be frozen")
+	}
+}
+
+func TestUpdateEffectiveSizesRecomputesNonFrozenSegments(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.AddSegment()
+	segments.Memory.Insert(memory.NewRelocatable(0, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	segments.Memory.Insert(memory.NewRelocatable(1, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	segments.FreezeSegment(0, 1)
+	segments.ComputeEffectiveSizes()
+
+	segments.Memory.Insert(memory.NewRelocatable(1, 2), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	segments.UpdateEffectiveSizes()
+
+	expectedSizes := map[uint]uint{0: 1, 1: 3}
+	if !reflect.DeepEqual(expectedSizes, segments.SegmentUsedSizes) {
+		t.Errorf("Segment sizes are not the same. Expected %v, got %v", expectedSizes, segments.SegmentUsedSizes)
+	}
+}
+
+func TestComputeEffectiveSizesCachedWithEmptySegment(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.AddSegment()
+	segments.Memory.Insert(memory.NewRelocatable(0, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	segments.FreezeSegment(0, 1)
+	// Only the frozen segment has a size, as the other one is empty
+	segments.ComputeEffectiveSizes()
+
+	segments.Memory.Insert(memory.NewRelocatable(1, 2), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	expectedSizes := map[uint]uint{0: 1}
+	if sizes := segments.ComputeEffectiveSizes(); !reflect.DeepEqual(sizes, expectedSizes) {
+		t.Errorf("Sizes should be cached. Expected %v, got %v", expectedSizes, sizes)
+	}
+	expectedSizes = map[uint]uint{0: 1, 1: 3}
+	if sizes := segments.UpdateEffectiveSizes(); !reflect.DeepEqual(sizes, expectedSizes) {
+		t.Errorf("Wrong updated sizes. Expected %v, got %v", expectedSizes, sizes)
+	}
+}
+
+func benchmarkUpdateEffectiveSizes(b *testing.B, freezeProgram bool) {
+	// A large program segment followed by a small execution segment, as in a program needing many padding steps
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.AddSegment()
+	programSize := uint(100000)
+	for i := uint(0); i < programSize; i++ {
+		segments.Memory.Insert(memory.NewRelocatable(0, i), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(i))))
+	}
+	for i := uint(0); i < 100; i++ {
+		segments.Memory.Insert(memory.NewRelocatable(1, i), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(i))))
+	}
+	if freezeProgram {
+		segments.FreezeSegment(0, programSize)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		segments.UpdateEffectiveSizes()
+	}
+}
+
+func BenchmarkUpdateEffectiveSizesNoFrozenSegments(b *testing.B) {
+	benchmarkUpdateEffectiveSizes(b, false)
+}
+
+func BenchmarkUpdateEffectiveSizesFrozenProgramSegment(b *testing.B) {
+	benchmarkUpdateEffectiveSizes(b, true)
+}
+
+func TestAddSegmentWithCapacity(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.Memory.Insert(memory.NewRelocatable(0, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)))
+
+	ptr, err := segments.AddSegmentWithCapacity(100)
+	if err != nil {
+		t.Errorf("AddSegmentWithCapacity failed with error: %s", err)
+		return
+	}
+	if ptr != memory.NewRelocatable(1, 0) || segments.Memory.NumSegments() != 2 {
+		t.Errorf("Wrong segment added: %+v, segments: %d", ptr, segments.Memory.NumSegments())
+	}
+	// Preallocating keeps the values already stored
+	value, err := segments.Memory.GetFelt(memory.NewRelocatable(0, 0))
+	if err != nil || value != lambdaworks.FeltFromUint64(7) {
+		t.Errorf("Expected the previously stored value to be kept, got %v, %v", value, err)
+	}
+	// The new segment can be filled past its capacity
+	for i := uint(0); i < 150; i++ {
+		err = segments.Memory.Insert(ptr.AddUint(i), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(i))))
+		if err != nil {
+			t.Errorf("Insert failed with error: %s", err)
+			return
+		}
+	}
+	if len(segments.Memory.GetSegment(1)) != 150 {
+		t.Errorf("Expected 150 cells in the new segment, got %d", len(segments.Memory.GetSegment(1)))
+	}
+}
+
+func TestAddSegmentWithCapacityTooManySegments(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.MaxSegments = 1
+	segments.AddSegment()
+	_, err := segments.AddSegmentWithCapacity(100)
+	if !errors.Is(err, memory.ErrTooManySegments) {
+		t.Errorf("Expected ErrTooManySegments, got %v", err)
+	}
+}
+
+func benchmarkInsertSegment(b *testing.B, preallocate bool) {
+	size := uint(1000000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		segments := memory.NewMemorySegmentManager()
+		var base memory.Relocatable
+		if preallocate {
+			base, _ = segments.AddSegmentWithCapacity(size)
+		} else {
+			base, _ = segments.AddSegment()
+		}
+		for j := uint(0); j < size; j++ {
+			segments.Memory.Insert(base.AddUint(j), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(uint64(j))))
+		}
+	}
+}
+
+func BenchmarkInsertSegmentWithoutPreallocation(b *testing.B) {
+	benchmarkInsertSegment(b, false)
+}
+
+func BenchmarkInsertSegmentWithPreallocation(b *testing.B) {
+	benchmarkInsertSegment(b, true)
+}
+
+func TestGetPublicMemoryAddressesOutputPages(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	// Program, execution, output and range_check segments
+	for i := 0; i < 4; i++ {
+		segments.AddSegment()
+	}
+	segments.SegmentUsedSizes = map[uint]uint{0: 3, 1: 10, 2: 2, 3: 4}
+	segments.Finalize(nil, 0, &[]uint{0, 1, 2})
+	segments.Finalize(nil, 1, &[]uint{1, 4})
+	size := uint(2)
+	segments.Finalize(&size, 2, &[]uint{0, 1})
+	segments.Finalize(nil, 3, nil)
+
+	relocationTable, err := segments.RelocateSegments()
+	if err != nil {
+		t.Errorf("RelocateSegments failed with error: %s", err)
+	}
+	addresses, err := segments.GetPublicMemoryAddresses(&relocationTable)
+	if err != nil {
+		t.Errorf("GetPublicMemoryAddresses failed with error: %s", err)
+	}
+	// Segments start at 1, 4, 14 and 16 respectively
+	expectedAddresses := []uint{1, 2, 3, 5, 8, 14, 15}
+	if !reflect.DeepEqual(expectedAddresses, addresses) {
+		t.Errorf("Wrong public memory addresses. Expected %v, got %v", expectedAddresses, addresses)
+	}
+}
+
+func TestGetPublicMemoryAddressesMissingSegment(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.AddSegment()
+	segments.Finalize(nil, 1, &[]uint{0})
+
+	relocationTable := []uint{1}
+	_, err := segments.GetPublicMemoryAddresses(&relocationTable)
+	if err != memory.ErrMalformedPublicMemory {
+		t.Errorf("GetPublicMemoryAddresses should have failed with ErrMalformedPublicMemory, got: %v", err)
+	}
+}
+
+func TestGetFinalizedSize(t *testing.T) {
+	segments := memory.NewMemorySegmentManager()
+	segments.AddSegment()
+	segments.AddSegment()
+	segments.SegmentUsedSizes = map[uint]uint{0: 3, 1: 2}
+	size := uint(5)
+	segments.Finalize(&size, 0, nil)
+	segments.Finalize(nil, 1, nil)
+
+	finalizedSize, ok := segments.GetFinalizedSize(0)
+	if !ok || *finalizedSize != 5 {
+		t.Errorf("Expected segment 0 to be finalized with size 5, got %v, %v", finalizedSize, ok)
+	}
+	finalizedSize, ok = segments.GetFinalizedSize(1)
+	if ok || finalizedSize != nil {
+		t.Errorf("Expected segment 1 to have no finalized size, got %v", *finalizedSize)
+	}
+}
@@ -1,11 +1,21 @@
 package vm
 
 import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
 	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
+	"github.com/lambdaclass/cairo-vm.go/pkg/starknet_crypto"
+	"github.com/lambdaclass/cairo-vm.go/pkg/utils"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
+	"github.com/pkg/errors"
 )
 
+var ErrPrimeMismatch = errors.New("Program prime doesn't match the field's prime")
+
 type Identifier struct {
 	FullName   string
 	Members    map[string]any
@@ -18,6 +28,8 @@ type Identifier struct {
 }
 
 type Program struct {
+	// The prime the program was compiled for, as found in the compiled json
+	Prime            string
 	Data             []memory.MaybeRelocatable
 	Builtins         []string
 	Identifiers      map[string]Identifier
@@ -25,23 +37,37 @@ type Program struct {
 	ReferenceManager parser.ReferenceManager
 	Start            uint
 	End              uint
+	// Source locations of the program's instructions, indexed by pc.
+	// Empty if the program was compiled without debug info
+	InstructionLocations map[uint]parser.InstructionLocation
+	// Hinted class hash of the Starknet contract class the program was loaded from, which covers the parts of the
+	// class json the program doesn't keep (see starknet.ComputeHintedClassHash). Zero for programs not loaded from
+	// a contract class
+	HintedClassHash lambdaworks.Felt
 }
 
-func DeserializeProgramJson(compiledProgram parser.CompiledJson) Program {
+// Builds a Program from its compiled json. Fails with ErrPrimeMismatch if the program was compiled for a different prime
+func DeserializeProgramJson(compiledProgram parser.CompiledJson) (Program, error) {
 	var program Program
 
-	hexData := compiledProgram.Data
-	for _, hexVal := range hexData {
-		felt := lambdaworks.FeltFromHex(hexVal)
-		program.Data = append(program.Data, *memory.NewMaybeRelocatableFelt(felt))
+	program.Prime = compiledProgram.Prime
+	err := program.ValidatePrime()
+	if err != nil {
+		return Program{}, err
+	}
+
+	for i, entry := range compiledProgram.Data {
+		value, err := parseProgramDataEntry(entry)
+		if err != nil {
+			return Program{}, errors.Wrapf(err, "Invalid program data at position %d", i)
+		}
+		program.Data = append(program.Data, *value)
 	}
 	program.Builtins = compiledProgram.Builtins
 	program.Identifiers = make(map[string]Identifier)
 
-	start := uint(compiledProgram.Identifiers["__main__.__start__"].PC)
-	end := uint(compiledProgram.Identifiers["__main__.__end__"].PC)
-	program.Start = start
-	program.End = end
+	program.Start = getLabelPc(compiledProgram.Identifiers, "__start__")
+	program.End = getLabelPc(compiledProgram.Identifiers, "__end__")
 
 	for key, identifier := range compiledProgram.Identifiers {
 		var programIdentifier Identifier
@@ -58,7 +84,107 @@ func DeserializeProgramJson(compiledProgram parser.CompiledJson) Program {
 	program.Hints = compiledProgram.Hints
 	program.ReferenceManager = compiledProgram.ReferenceManager
 
-	return program
+	program.InstructionLocations = make(map[uint]parser.InstructionLocation)
+	for pc, location := range compiledProgram.DebugInfo.InstructionLocation {
+		pcOffset, err := strconv.ParseUint(pc, 10, 64)
+		if err != nil {
+			continue
+		}
+		program.InstructionLocations[uint(pcOffset)] = location
+	}
+
+	return program, nil
+}
+
+// Parses an element of the compiled program's data. Felts are hex encoded, with or without the 0x prefix,
+// while relocatable values use the {segment:offset} format
+func parseProgramDataEntry(entry string) (*memory.MaybeRelocatable, error) {
+	if strings.HasPrefix(entry, "{") && strings.HasSuffix(entry, "}") {
+		segment, offset, found := strings.Cut(entry[1:len(entry)-1], ":")
+		if !found {
+			return nil, errors.Errorf("Malformed relocatable value %s", entry)
+		}
+		segmentIndex, err := strconv.Atoi(strings.TrimSpace(segment))
+		if err != nil {
+			return nil, errors.Errorf("Malformed relocatable value %s", entry)
+		}
+		offsetValue, err := strconv.ParseUint(strings.TrimSpace(offset), 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("Malformed relocatable value %s", entry)
+		}
+		return memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(segmentIndex, uint(offsetValue))), nil
+	}
+	hexValue := strings.TrimPrefix(strings.TrimPrefix(entry, "0x"), "0X")
+	value, ok := new(big.Int).SetString(hexValue, 16)
+	if !ok || value.Sign() < 0 {
+		return nil, errors.Errorf("Malformed hex value %s", entry)
+	}
+	return memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromBigInt(value)), nil
+}
+
+// Checks that the program was compiled for the Cairo prime, which is the field the vm operates on.
+// Programs that don't specify their prime are rejected, as the compiler always outputs it
+func (p *Program) ValidatePrime() error {
+	if p.Prime == "" {
+		return fmt.Errorf("%w: expected %s, got no prime", ErrPrimeMismatch, lambdaworks.CAIRO_PRIME_HEX)
+	}
+	prime, ok := new(big.Int).SetString(p.Prime, 0)
+	fieldPrime, _ := new(big.Int).SetString(lambdaworks.CAIRO_PRIME_HEX, 0)
+	if !ok || prime.Cmp(fieldPrime) != 0 {
+		return fmt.Errorf("%w: expected %s, got %s", ErrPrimeMismatch, lambdaworks.CAIRO_PRIME_HEX, p.Prime)
+	}
+	return nil
+}
+
+// Returns true if the program uses the builtin with the given name
+func (p *Program) HasBuiltin(name string) bool {
+	for _, builtin := range p.Builtins {
+		if builtin == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks that the program's builtins are known and appear in the order the vm expects them,
+// which allows validating a program before creating a runner for it
+func (p *Program) ValidateBuiltins() error {
+	return utils.CheckBuiltinsSubsequence(p.Builtins)
+}
+
+// Computes a hash identifying the program by its data and builtins, suitable as a cache key.
+// It's the Pedersen chain (see starknet_crypto.PedersenHashArray) of the hashes of the builtin names,
+// encoded as short strings, and of the program data. Relocatable data entries are hashed as H(segment, offset)
+func (p *Program) Hash() lambdaworks.Felt {
+	builtins := make([]lambdaworks.Felt, 0, len(p.Builtins))
+	for _, builtin := range p.Builtins {
+		builtins = append(builtins, lambdaworks.FeltFromBigInt(new(big.Int).SetBytes([]byte(builtin))))
+	}
+	data := make([]lambdaworks.Felt, 0, len(p.Data))
+	for _, entry := range p.Data {
+		if felt, ok := entry.GetFelt(); ok {
+			data = append(data, felt)
+		} else {
+			relocatable, _ := entry.GetRelocatable()
+			segment := lambdaworks.FeltFromDecString(strconv.Itoa(relocatable.SegmentIndex))
+			offset := lambdaworks.FeltFromUint64(uint64(relocatable.Offset))
+			data = append(data, starknet_crypto.PedersenHash(segment, offset))
+		}
+	}
+	return starknet_crypto.PedersenHashArray([]lambdaworks.Felt{
+		starknet_crypto.PedersenHashArray(builtins),
+		starknet_crypto.PedersenHashArray(data),
+	})
+}
+
+// Returns the pc of the given proof mode label (`__start__` or `__end__`).
+// Programs such as the bootloader declare it at the top level, otherwise the label
+// declared in the main module is used
+func getLabelPc(identifiers map[string]parser.Identifier, label string) uint {
+	if identifier, ok := identifiers[label]; ok {
+		return uint(identifier.PC)
+	}
+	return uint(identifiers["__main__."+label].PC)
 }
 
 func (p *Program) ExtractConstants() map[string]lambdaworks.Felt {
@@ -70,3 +196,24 @@ func (p *Program) ExtractConstants() map[string]lambdaworks.Felt {
 	}
 	return constants
 }
+
+// Returns the program's identifiers whose type matches the given one (e.g. "function", "const", "struct")
+func (p *Program) GetIdentifiersByType(identifierType string) map[string]Identifier {
+	identifiers := make(map[string]Identifier)
+	for name, identifier := range p.Identifiers {
+		if identifier.Type == identifierType {
+			identifiers[name] = identifier
+		}
+	}
+	return identifiers
+}
+
+// Returns the source location of the instruction at the given pc offset.
+// Returns false if there is no location for it (e.g. the program was compiled without debug info)
+func (p *Program) GetLocationForPc(pc uint) (*parser.InstructionLocation, bool) {
+	location, ok := p.InstructionLocations[pc]
+	if !ok {
+		return nil, false
+	}
+	return &location, true
+}
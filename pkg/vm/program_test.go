@@ -1,17 +1,123 @@
 package vm_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	"github.com/lambdaclass/cairo-vm.go/pkg/parser"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
+	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
 )
 
 func TestNewProgram(t *testing.T) {
 
 }
 
+func TestDeserializeProgramJsonStartEndFromMain(t *testing.T) {
+	compiledProgram := parser.CompiledJson{
+		Prime: lambdaworks.CAIRO_PRIME_HEX,
+		Identifiers: map[string]parser.Identifier{
+			"__main__.__start__": {PC: 2, Type: "label"},
+			"__main__.__end__":   {PC: 6, Type: "label"},
+		},
+	}
+	program, err := vm.DeserializeProgramJson(compiledProgram)
+	if err != nil {
+		t.Errorf("DeserializeProgramJson failed with error: %s", err)
+		return
+	}
+	if program.Start != 2 || program.End != 6 {
+		t.Errorf("Wrong start/end, expected (2, 6), got (%d, %d)", program.Start, program.End)
+	}
+}
+
+func TestDeserializeProgramJsonStartEndTopLevelLabels(t *testing.T) {
+	compiledProgram := parser.CompiledJson{
+		Prime: lambdaworks.CAIRO_PRIME_HEX,
+		Identifiers: map[string]parser.Identifier{
+			"__start__":          {PC: 4, Type: "label"},
+			"__end__":            {PC: 8, Type: "label"},
+			"__main__.__start__": {PC: 2, Type: "label"},
+			"__main__.__end__":   {PC: 6, Type: "label"},
+		},
+	}
+	program, err := vm.DeserializeProgramJson(compiledProgram)
+	if err != nil {
+		t.Errorf("DeserializeProgramJson failed with error: %s", err)
+		return
+	}
+	if program.Start != 4 || program.End != 8 {
+		t.Errorf("Wrong start/end, expected (4, 8), got (%d, %d)", program.Start, program.End)
+	}
+}
+
+func TestDeserializeProgramJsonCairoPrime(t *testing.T) {
+	compiledProgram := parser.CompiledJson{Prime: "0x800000000000011000000000000000000000000000000000000000000000001"}
+	program, err := vm.DeserializeProgramJson(compiledProgram)
+	if err != nil {
+		t.Errorf("DeserializeProgramJson failed with error: %s", err)
+	}
+	if program.Prime != compiledProgram.Prime {
+		t.Errorf("Wrong prime, expected %s, got %s", compiledProgram.Prime, program.Prime)
+	}
+}
+
+func TestDeserializeProgramJsonWrongPrime(t *testing.T) {
+	compiledProgram := parser.CompiledJson{Prime: "0x800000000000011000000000000000000000000000000000000000000000003"}
+	_, err := vm.DeserializeProgramJson(compiledProgram)
+	if !errors.Is(err, vm.ErrPrimeMismatch) {
+		t.Errorf("DeserializeProgramJson should have failed with ErrPrimeMismatch, got %v", err)
+	}
+}
+
+func TestDeserializeProgramJsonMissingPrime(t *testing.T) {
+	_, err := vm.DeserializeProgramJson(parser.CompiledJson{})
+	if !errors.Is(err, vm.ErrPrimeMismatch) {
+		t.Errorf("DeserializeProgramJson should have failed with ErrPrimeMismatch, got %v", err)
+	}
+}
+
+func TestValidatePrimeMalformed(t *testing.T) {
+	program := vm.Program{Prime: "not a prime"}
+	if err := program.ValidatePrime(); !errors.Is(err, vm.ErrPrimeMismatch) {
+		t.Errorf("ValidatePrime should have failed with ErrPrimeMismatch, got %v", err)
+	}
+}
+
+func TestDeserializeProgramJsonMixedData(t *testing.T) {
+	compiledProgram := parser.CompiledJson{
+		Prime: lambdaworks.CAIRO_PRIME_HEX,
+		Data:  []string{"0x1", "0x480680017fff8000", "a", "0X1F", "{1:4}", "0x800000000000011000000000000000000000000000000000000000000000000"},
+	}
+	program, err := vm.DeserializeProgramJson(compiledProgram)
+	if err != nil {
+		t.Errorf("DeserializeProgramJson failed with error: %s", err)
+		return
+	}
+	expected := []memory.MaybeRelocatable{
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltOne()),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480680017fff8000)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(10)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(31)),
+		*memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 4)),
+		*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromDecString("-1")),
+	}
+	if !reflect.DeepEqual(program.Data, expected) {
+		t.Errorf("Wrong program data, expected %v, got %v", expected, program.Data)
+	}
+}
+
+func TestDeserializeProgramJsonInvalidData(t *testing.T) {
+	for _, entry := range []string{"0xzz", "", "{1}", "{a:2}"} {
+		_, err := vm.DeserializeProgramJson(parser.CompiledJson{Prime: lambdaworks.CAIRO_PRIME_HEX, Data: []string{entry}})
+		if err == nil {
+			t.Errorf("DeserializeProgramJson should have failed for data entry %q", entry)
+		}
+	}
+}
+
 func TestExtractConstantsEmpty(t *testing.T) {
 	program := vm.Program{}
 	expectedConstants := make(map[string]lambdaworks.Felt)
@@ -51,3 +157,160 @@ func TestExtractConstants(t *testing.T) {
 	}
 
 }
+
+func TestGetIdentifiersByTypeFunction(t *testing.T) {
+	program := vm.Program{
+		Identifiers: map[string]vm.Identifier{
+			"__main__.main": {
+				PC:   0,
+				Type: "function",
+			},
+			"__main__.main.Args": {
+				Type: "struct",
+			},
+			"__main__.A": {
+				Value: lambdaworks.FeltFromUint64(7),
+				Type:  "const",
+			},
+		},
+	}
+	expectedIdentifiers := map[string]vm.Identifier{
+		"__main__.main": {
+			PC:   0,
+			Type: "function",
+		},
+	}
+	identifiers := program.GetIdentifiersByType("function")
+	if !reflect.DeepEqual(identifiers, expectedIdentifiers) {
+		t.Errorf("Wrong Identifiers, expected %v, got %v", expectedIdentifiers, identifiers)
+	}
+}
+
+func TestGetIdentifiersByTypeNoMatches(t *testing.T) {
+	program := vm.Program{}
+	identifiers := program.GetIdentifiersByType("function")
+	if len(identifiers) != 0 {
+		t.Errorf("Wrong Identifiers, expected none, got %v", identifiers)
+	}
+}
+
+func TestGetLocationForPc(t *testing.T) {
+	compiledProgram := parser.CompiledJson{
+		Prime: lambdaworks.CAIRO_PRIME_HEX,
+		DebugInfo: parser.DebugInfo{
+			InstructionLocation: map[string]parser.InstructionLocation{
+				"2": {
+					AccessibleScopes: []string{"__main__", "__main__.main"},
+					Inst: parser.Location{
+						StartLine: 4,
+						EndLine:   4,
+						StartCol:  5,
+						EndCol:    18,
+						InputFile: map[string]string{"filename": "main.cairo"},
+					},
+				},
+			},
+		},
+	}
+	program, err := vm.DeserializeProgramJson(compiledProgram)
+	if err != nil {
+		t.Errorf("DeserializeProgramJson failed with error: %s", err)
+		return
+	}
+	location, ok := program.GetLocationForPc(2)
+	if !ok {
+		t.Errorf("GetLocationForPc should have found a location for pc 2")
+	}
+	if location.Inst.StartLine != 4 || location.Inst.InputFile["filename"] != "main.cairo" {
+		t.Errorf("Wrong location for pc 2, got %+v", location.Inst)
+	}
+	if _, ok := program.GetLocationForPc(3); ok {
+		t.Errorf("GetLocationForPc should not have found a location for pc 3")
+	}
+}
+
+func TestGetLocationForPcNoDebugInfo(t *testing.T) {
+	program, err := vm.DeserializeProgramJson(parser.CompiledJson{Prime: lambdaworks.CAIRO_PRIME_HEX})
+	if err != nil {
+		t.Errorf("DeserializeProgramJson failed with error: %s", err)
+		return
+	}
+	if _, ok := program.GetLocationForPc(0); ok {
+		t.Errorf("GetLocationForPc should return false for programs without debug info")
+	}
+}
+
+func TestProgramHasBuiltin(t *testing.T) {
+	program := vm.Program{Builtins: []string{"output", "range_check"}}
+	if !program.HasBuiltin("range_check") {
+		t.Errorf("Program should have the range_check builtin")
+	}
+	if program.HasBuiltin("pedersen") {
+		t.Errorf("Program should not have the pedersen builtin")
+	}
+}
+
+func TestValidateBuiltinsOrdered(t *testing.T) {
+	program := vm.Program{Builtins: []string{"output", "pedersen", "range_check", "bitwise"}}
+	if err := program.ValidateBuiltins(); err != nil {
+		t.Errorf("ValidateBuiltins failed with error: %s", err)
+	}
+}
+
+func TestValidateBuiltinsWrongOrder(t *testing.T) {
+	program := vm.Program{Builtins: []string{"range_check", "pedersen"}}
+	if err := program.ValidateBuiltins(); err == nil {
+		t.Errorf("ValidateBuiltins should fail for builtins out of order")
+	}
+}
+
+func TestValidateBuiltinsUnknownBuiltin(t *testing.T) {
+	program := vm.Program{Builtins: []string{"output", "unknown"}}
+	if err := program.ValidateBuiltins(); err == nil {
+		t.Errorf("ValidateBuiltins should fail for unknown builtins")
+	}
+}
+
+func TestValidateBuiltinsWrongOrderAfterGap(t *testing.T) {
+	program := vm.Program{Builtins: []string{"range_check", "ecdsa", "pedersen"}}
+	if err := program.ValidateBuiltins(); err == nil {
+		t.Errorf("ValidateBuiltins should fail for builtins out of order")
+	}
+}
+
+func hashTestProgram() vm.Program {
+	return vm.Program{
+		Data: []memory.MaybeRelocatable{
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x480680017fff8000)),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltOne()),
+			*memory.NewMaybeRelocatableRelocatable(memory.NewRelocatable(1, 2)),
+			*memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(0x208b7fff7fff7ffe)),
+		},
+		Builtins: []string{"output", "pedersen"},
+	}
+}
+
+func TestProgramHashIdenticalPrograms(t *testing.T) {
+	a := hashTestProgram()
+	b := hashTestProgram()
+	if a.Hash() != b.Hash() {
+		t.Errorf("Identical programs should have the same hash")
+	}
+}
+
+func TestProgramHashModifiedProgram(t *testing.T) {
+	program := hashTestProgram()
+	hash := program.Hash()
+
+	modifiedData := hashTestProgram()
+	modifiedData.Data[1] = *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2))
+	if modifiedData.Hash() == hash {
+		t.Errorf("Programs with different data should have different hashes")
+	}
+
+	modifiedBuiltins := hashTestProgram()
+	modifiedBuiltins.Builtins = []string{"output"}
+	if modifiedBuiltins.Hash() == hash {
+		t.Errorf("Programs with different builtins should have different hashes")
+	}
+}
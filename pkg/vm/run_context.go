@@ -2,6 +2,7 @@ package vm
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
@@ -15,6 +16,11 @@ type RunContext struct {
 	Fp memory.Relocatable
 }
 
+// Returns the registers in the format `pc: {segment:offset}, ap: {segment:offset}, fp: {segment:offset}`
+func (run_context RunContext) String() string {
+	return fmt.Sprintf("pc: %s, ap: %s, fp: %s", run_context.Pc.ToString(), run_context.Ap.ToString(), run_context.Fp.ToString())
+}
+
 func (run_context RunContext) ComputeDstAddr(instruction Instruction) (memory.Relocatable, error) {
 	var base_addr memory.Relocatable
 	switch instruction.DstReg {
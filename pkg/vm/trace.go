@@ -16,3 +16,12 @@ type RelocatedTraceEntry struct {
 	Ap lambdaworks.Felt
 	Fp lambdaworks.Felt
 }
+
+// Relocates the registers of a trace entry, turning each of them into its offset in the relocated memory
+func RelocateTraceEntry(entry TraceEntry, relocationTable []uint) RelocatedTraceEntry {
+	return RelocatedTraceEntry{
+		Pc: lambdaworks.FeltFromUint64(uint64(entry.Pc.RelocateAddress(&relocationTable))),
+		Ap: lambdaworks.FeltFromUint64(uint64(entry.Ap.RelocateAddress(&relocationTable))),
+		Fp: lambdaworks.FeltFromUint64(uint64(entry.Fp.RelocateAddress(&relocationTable))),
+	}
+}
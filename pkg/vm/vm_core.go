@@ -35,6 +35,10 @@ type VirtualMachine struct {
 	RunFinished     bool
 	RcLimitsMin     *int
 	RcLimitsMax     *int
+	// When set, Step doesn't record trace entries
+	TraceDisabled bool
+	// Amount of times the instruction at each pc offset was executed, nil unless profiling is enabled
+	profile map[uint]uint
 }
 
 func NewVirtualMachine() *VirtualMachine {
@@ -45,14 +49,99 @@ func NewVirtualMachine() *VirtualMachine {
 	return &VirtualMachine{Segments: segments, BuiltinRunners: builtin_runners, Trace: trace, RelocatedTrace: relocatedTrace}
 }
 
+// Returns a deep copy of the VM, including its memory segments, run context and builtin runners.
+// The copy can be run independently (e.g. for speculative execution) without affecting the original
+func (v *VirtualMachine) Clone() *VirtualMachine {
+	builtinRunners := make([]builtins.BuiltinRunner, 0, len(v.BuiltinRunners))
+	for _, builtin := range v.BuiltinRunners {
+		builtinRunners = append(builtinRunners, builtin.Clone())
+	}
+	var relocatedMemory map[uint]lambdaworks.Felt
+	if v.RelocatedMemory != nil {
+		relocatedMemory = make(map[uint]lambdaworks.Felt, len(v.RelocatedMemory))
+		for addr, value := range v.RelocatedMemory {
+			relocatedMemory[addr] = value
+		}
+	}
+	var rcLimitsMin, rcLimitsMax *int
+	if v.RcLimitsMin != nil {
+		rcLimitsMin = new(int)
+		*rcLimitsMin = *v.RcLimitsMin
+	}
+	if v.RcLimitsMax != nil {
+		rcLimitsMax = new(int)
+		*rcLimitsMax = *v.RcLimitsMax
+	}
+	// The validation rules registered by the builtins may refer to their runners, so the clone's rules
+	// are registered again from the cloned builtins instead of being shared with the original
+	segments := v.Segments.Clone()
+	segments.Memory.ClearValidationRules()
+	for _, builtin := range builtinRunners {
+		if v.Segments.Memory.HasValidationRule(uint(builtin.Base().SegmentIndex)) {
+			builtin.AddValidationRule(&segments.Memory)
+		}
+	}
+	return &VirtualMachine{
+		RunContext:      v.RunContext,
+		CurrentStep:     v.CurrentStep,
+		Segments:        segments,
+		BuiltinRunners:  builtinRunners,
+		Trace:           append(make([]TraceEntry, 0, len(v.Trace)), v.Trace...),
+		RelocatedTrace:  append(make([]RelocatedTraceEntry, 0, len(v.RelocatedTrace)), v.RelocatedTrace...),
+		RelocatedMemory: relocatedMemory,
+		RunFinished:     v.RunFinished,
+		RcLimitsMin:     rcLimitsMin,
+		RcLimitsMax:     rcLimitsMax,
+		TraceDisabled:   v.TraceDisabled,
+		profile:         v.GetProfile(),
+	}
+}
+
+// Returns a new VM without any run state, configured like v: trace recording, profiling (with no executions
+// counted), and the limits on the amount of segments and memory cells
+func (v *VirtualMachine) NewWithSameConfig() *VirtualMachine {
+	newVm := NewVirtualMachine()
+	newVm.TraceDisabled = v.TraceDisabled
+	if v.profile != nil {
+		newVm.EnableProfiling()
+	}
+	newVm.Segments.MaxSegments = v.Segments.MaxSegments
+	newVm.Segments.Memory.SetMaxCells(v.Segments.Memory.MaxCells())
+	return newVm
+}
+
+// Starts counting the executions of each instruction during Step, which can be retrieved with GetProfile
+func (v *VirtualMachine) EnableProfiling() {
+	if v.profile == nil {
+		v.profile = make(map[uint]uint)
+	}
+}
+
+// Returns a copy of the amount of times each instruction was executed, indexed by pc offset.
+// Returns nil if profiling wasn't enabled
+func (v *VirtualMachine) GetProfile() map[uint]uint {
+	if v.profile == nil {
+		return nil
+	}
+	profile := make(map[uint]uint, len(v.profile))
+	for pc, count := range v.profile {
+		profile[pc] = count
+	}
+	return profile
+}
+
 func (v *VirtualMachine) Step(hintProcessor HintProcessor, hintDataMap *map[uint][]any, constants *map[string]lambdaworks.Felt, execScopes *types.ExecutionScopes) error {
-	// Run Hint
+	// Run Hints (in the order they were declared for this pc)
 	hintDatas, ok := (*hintDataMap)[v.RunContext.Pc.Offset]
 	if ok {
 		for i := 0; i < len(hintDatas); i++ {
 			err := hintProcessor.ExecuteHint(v, &hintDatas[i], constants, execScopes)
 			if err != nil {
-				return err
+				hintError := &HintError{Pc: v.RunContext.Pc, Cause: err}
+				if codeProvider, ok := hintDatas[i].(HintCodeProvider); ok {
+					hintError.Code = codeProvider.HintCode()
+				}
+				return hintError
 			}
 		}
 	}
@@ -78,6 +167,9 @@ func (v *VirtualMachine) Step(hintProcessor HintProcessor, hintDataMap *map[uint
 		return err
 	}
 
+	if v.profile != nil {
+		v.profile[v.RunContext.Pc.Offset]++
+	}
 	return v.RunInstruction(&instruction)
 }
 
@@ -92,7 +184,9 @@ func (v *VirtualMachine) RunInstruction(instruction *Instruction) error {
 		return err
 	}
 
-	v.Trace = append(v.Trace, TraceEntry{Pc: v.RunContext.Pc, Ap: v.RunContext.Ap, Fp: v.RunContext.Fp})
+	if !v.TraceDisabled {
+		v.Trace = append(v.Trace, TraceEntry{Pc: v.RunContext.Pc, Ap: v.RunContext.Ap, Fp: v.RunContext.Fp})
+	}
 
 	v.Segments.Memory.MarkAsAccessed(operandsAddresses.DstAddr)
 	v.Segments.Memory.MarkAsAccessed(operandsAddresses.Op0Addr)
@@ -140,11 +234,7 @@ func (v *VirtualMachine) RelocateTrace(relocationTable *[]uint) error {
 	}
 
 	for _, entry := range v.Trace {
-		v.RelocatedTrace = append(v.RelocatedTrace, RelocatedTraceEntry{
-			Pc: lambdaworks.FeltFromUint64(uint64(entry.Pc.RelocateAddress(relocationTable))),
-			Ap: lambdaworks.FeltFromUint64(uint64(entry.Ap.RelocateAddress(relocationTable))),
-			Fp: lambdaworks.FeltFromUint64(uint64(entry.Fp.RelocateAddress(relocationTable))),
-		})
+		v.RelocatedTrace = append(v.RelocatedTrace, RelocateTraceEntry(entry, *relocationTable))
 	}
 
 	return nil
@@ -204,9 +294,10 @@ func (vm *VirtualMachine) EndRun() error {
 func (vm *VirtualMachine) VerifyAutoDeductions() error {
 	for _, builtin := range vm.BuiltinRunners {
 		var index = builtin.Base()
-		for relocatableAddress, value := range vm.Segments.Memory.Data {
-			if relocatableAddress.SegmentIndex != index.SegmentIndex {
-				continue
+		for _, relocatableAddress := range vm.Segments.Memory.SegmentAddresses(index.SegmentIndex) {
+			value, err := vm.Segments.Memory.Get(relocatableAddress)
+			if err != nil {
+				return err
 			}
 
 			deducedMemoryCell, err := builtin.DeduceMemoryCell(relocatableAddress, &vm.Segments.Memory)
@@ -218,7 +309,7 @@ func (vm *VirtualMachine) VerifyAutoDeductions() error {
 				continue
 			}
 
-			if *deducedMemoryCell != value {
+			if *deducedMemoryCell != *value {
 				return &VirtualMachineError{fmt.Sprintf("InconsistentAutoDeduction: %s", builtin.Name())}
 			}
 		}
@@ -416,7 +507,9 @@ func (vm *VirtualMachine) ComputeOperands(instruction Instruction) (Operands, Op
 		deducedDst := vm.DeduceDst(instruction, res)
 		dst = deducedDst
 		if dst != nil {
-			vm.Segments.Memory.Insert(dstAddr, dst)
+			if err := vm.Segments.Memory.InsertIfAbsent(dstAddr, dst); err != nil {
+				return Operands{}, OperandsAddresses{}, err
+			}
 		}
 	}
 
@@ -451,7 +544,9 @@ func (vm *VirtualMachine) ComputeOp0Deductions(op0_addr memory.Relocatable, inst
 		}
 	}
 	if op0 != nil {
-		vm.Segments.Memory.Insert(op0_addr, op0)
+		if err := vm.Segments.Memory.InsertIfAbsent(op0_addr, op0); err != nil {
+			return *memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero()), nil, err
+		}
 	} else {
 		return *memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero()), nil, errors.New("Failed to compute or deduce op0")
 	}
@@ -478,7 +573,9 @@ func (vm *VirtualMachine) ComputeOp1Deductions(op1_addr memory.Relocatable, inst
 		}
 	}
 	if op1 != nil {
-		vm.Segments.Memory.Insert(op1_addr, op1)
+		if err := vm.Segments.Memory.InsertIfAbsent(op1_addr, op1); err != nil {
+			return *memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero()), err
+		}
 	} else {
 		return *memory.NewMaybeRelocatableFelt(lambdaworks.FeltZero()), errors.New("Failed to compute or deduce op1")
 	}
@@ -617,6 +714,16 @@ func (vm *VirtualMachine) WriteOutput(writer *bytes.Buffer) {
 	}
 }
 
+// Registers a callback that is called when the memory cell at addr is written, see Memory.AddWatchpoint
+func (vm *VirtualMachine) AddWatchpoint(addr memory.Relocatable, callback func(old *memory.MaybeRelocatable, new *memory.MaybeRelocatable)) {
+	vm.Segments.Memory.AddWatchpoint(addr, callback)
+}
+
+// Returns copies of the current ap, fp and pc registers
+func (vm *VirtualMachine) GetRunContext() (ap, fp, pc memory.Relocatable) {
+	return vm.RunContext.Ap, vm.RunContext.Fp, vm.RunContext.Pc
+}
+
 func (vm *VirtualMachine) GetBuiltinRunner(builtinName string) (*builtins.BuiltinRunner, error) {
 
 	for _, builtin := range vm.BuiltinRunners {
@@ -626,3 +733,18 @@ func (vm *VirtualMachine) GetBuiltinRunner(builtinName string) (*builtins.Builti
 	}
 	return nil, &VirtualMachineError{"BuiltinNotFound"}
 }
+
+// Returns the amount of instances used by each builtin runner, indexed by builtin name.
+// Builtins that aren't included in the run have no used cells, so they report zero instances
+func (vm *VirtualMachine) GetBuiltinUsedInstances() (map[string]uint, error) {
+	vm.Segments.ComputeEffectiveSizes()
+	usedInstances := make(map[string]uint, len(vm.BuiltinRunners))
+	for _, builtin := range vm.BuiltinRunners {
+		instances, err := builtin.GetUsedInstances(&vm.Segments)
+		if err != nil {
+			return nil, err
+		}
+		usedInstances[builtin.Name()] = instances
+	}
+	return usedInstances, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/lambdaclass/cairo-vm.go/pkg/builtins"
 	"github.com/lambdaclass/cairo-vm.go/pkg/lambdaworks"
+	starknet_crypto "github.com/lambdaclass/cairo-vm.go/pkg/starknet_crypto"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/cairo_run"
 	"github.com/lambdaclass/cairo-vm.go/pkg/vm/memory"
@@ -541,6 +542,21 @@ func TestRelocateTraceOneEntry(t *testing.T) {
 	}
 }
 
+func TestRelocateTraceEntry(t *testing.T) {
+	relocationTable := []uint{1, 10, 25}
+	entry := vm.TraceEntry{
+		Pc: memory.NewRelocatable(0, 3),
+		Ap: memory.NewRelocatable(1, 4),
+		Fp: memory.NewRelocatable(2, 2),
+	}
+
+	relocated := vm.RelocateTraceEntry(entry, relocationTable)
+	expected := vm.RelocatedTraceEntry{Pc: lambdaworks.FeltFromUint64(4), Ap: lambdaworks.FeltFromUint64(14), Fp: lambdaworks.FeltFromUint64(27)}
+	if relocated != expected {
+		t.Errorf("Relocated trace entry and expected entry are not the same. Expected %+v, got %+v", expected, relocated)
+	}
+}
+
 func TestWriteBinaryMemoryFile(t *testing.T) {
 	var relocatedMemory = make(map[uint]lambdaworks.Felt)
 	relocatedMemory[1] = lambdaworks.FeltFromUint64(66)
@@ -1080,3 +1096,158 @@ func TestGetFooBuiltinReturnsNilAndError(t *testing.T) {
 		t.Error("Obtained a non existant builtin, or didn't raise an error")
 	}
 }
+
+func TestCloneMemoryIsIndependent(t *testing.T) {
+	vm := vm.NewVirtualMachine()
+	vm.Segments.AddSegment()
+	vm.Segments.AddSegment()
+	addr := memory.NewRelocatable(1, 0)
+	vm.Segments.Memory.Insert(addr, memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)))
+	vm.RunContext.Pc = memory.NewRelocatable(0, 3)
+
+	clone := vm.Clone()
+	clone.Segments.Memory.Insert(memory.NewRelocatable(1, 1), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(8)))
+	clone.Segments.AddSegment()
+	clone.RunContext.Pc = memory.NewRelocatable(0, 5)
+
+	if _, err := vm.Segments.Memory.Get(memory.NewRelocatable(1, 1)); err == nil {
+		t.Errorf("Inserting into the clone's memory should not affect the original")
+	}
+	if vm.Segments.Memory.NumSegments() != 2 {
+		t.Errorf("Adding a segment to the clone should not affect the original")
+	}
+	if vm.RunContext.Pc != memory.NewRelocatable(0, 3) {
+		t.Errorf("Modifying the clone's run context should not affect the original")
+	}
+	value, err := clone.Segments.Memory.Get(addr)
+	if err != nil || *value != *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)) {
+		t.Errorf("The clone should contain the original memory values")
+	}
+}
+
+func TestCloneBuiltinRunnersAreIndependent(t *testing.T) {
+	vm := vm.NewVirtualMachine()
+	vm.BuiltinRunners = append(vm.BuiltinRunners, builtins.NewOutputBuiltinRunner())
+	clone := vm.Clone()
+	if len(clone.BuiltinRunners) != 1 || clone.BuiltinRunners[0].Name() != builtins.OUTPUT_BUILTIN_NAME {
+		t.Errorf("The clone should contain the original builtin runners")
+	}
+	clone.Segments.AddSegment()
+	clone.BuiltinRunners[0].InitializeSegments(&clone.Segments)
+	if vm.BuiltinRunners[0].Base() == clone.BuiltinRunners[0].Base() {
+		t.Errorf("Initializing the clone's builtin runner should not affect the original")
+	}
+}
+
+func TestCloneSignatureBuiltinIsIndependent(t *testing.T) {
+	vm := vm.NewVirtualMachine()
+	signatureBuiltin := builtins.NewSignatureBuiltinRunner(512)
+	vm.BuiltinRunners = append(vm.BuiltinRunners, signatureBuiltin)
+	signatureBuiltin.InitializeSegments(&vm.Segments)
+	signatureBuiltin.AddValidationRule(&vm.Segments.Memory)
+
+	privKey := lambdaworks.FeltFromHex("0x139fe4d6f02e666e86a6f58e65060f115cd3c185bd9e98bd829636931458f79")
+	msgHash := lambdaworks.FeltFromHex("0x6fea80189363a786037ed3e7ba546dad0ef7de49fccae0e31eb658b7dd4ea76")
+	r, s, err := starknet_crypto.SignDeterministic(privKey, msgHash)
+	if err != nil {
+		t.Errorf("SignDeterministic failed with error: %s", err)
+		return
+	}
+	pubKey := starknet_crypto.StarkCurveGenerator().ScalarMul(privKey.ToStarkCurveScalar()).X
+	pubKeyAddr := memory.NewRelocatable(0, 0)
+	vm.Segments.Memory.Data[pubKeyAddr] = *memory.NewMaybeRelocatableFelt(pubKey)
+	vm.Segments.Memory.Data[pubKeyAddr.AddUint(1)] = *memory.NewMaybeRelocatableFelt(msgHash)
+
+	clone := vm.Clone()
+	cloneSignatureBuiltin, ok := clone.BuiltinRunners[0].(*builtins.SignatureBuiltinRunner)
+	if !ok {
+		t.Errorf("The clone should contain a signature builtin runner")
+		return
+	}
+	builtins.AddSignature(cloneSignatureBuiltin, pubKeyAddr, builtins.Signature{R: r, S: s})
+
+	if _, err := builtins.ValidationRuleSignature(&clone.Segments.Memory, pubKeyAddr, cloneSignatureBuiltin); err != nil {
+		t.Errorf("The clone's signature builtin should verify the added signature, got error: %s", err)
+	}
+	if _, err := builtins.ValidationRuleSignature(&vm.Segments.Memory, pubKeyAddr, signatureBuiltin); err == nil {
+		t.Errorf("Adding a signature to the clone's builtin runner should not affect the original")
+	}
+
+	// The clone registers its own validation rules, so dropping the original's doesn't affect it
+	vm.Segments.Memory.ClearValidationRules()
+	if !clone.Segments.Memory.HasValidationRule(uint(cloneSignatureBuiltin.Base().SegmentIndex)) {
+		t.Errorf("The clone should register the validation rules of its builtin runners")
+	}
+}
+
+func TestGetBuiltinUsedInstancesTwoBuiltins(t *testing.T) {
+	vm := vm.NewVirtualMachine()
+	vm.BuiltinRunners = append(vm.BuiltinRunners, builtins.NewOutputBuiltinRunner(), builtins.NewRangeCheckBuiltinRunner(8))
+	for _, builtin := range vm.BuiltinRunners {
+		builtin.InitializeSegments(&vm.Segments)
+	}
+	vm.Segments.Memory.Insert(memory.NewRelocatable(0, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)))
+	vm.Segments.Memory.Insert(memory.NewRelocatable(1, 0), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(1)))
+	vm.Segments.Memory.Insert(memory.NewRelocatable(1, 1), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(2)))
+	vm.Segments.Memory.Insert(memory.NewRelocatable(1, 2), memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(3)))
+
+	usedInstances, err := vm.GetBuiltinUsedInstances()
+	if err != nil {
+		t.Errorf("GetBuiltinUsedInstances failed with error: %s", err)
+	}
+	expected := map[string]uint{builtins.OUTPUT_BUILTIN_NAME: 1, builtins.RANGE_CHECK_BUILTIN_NAME: 3}
+	if !reflect.DeepEqual(usedInstances, expected) {
+		t.Errorf("Wrong used instances. Expected %v, got %v", expected, usedInstances)
+	}
+}
+
+func TestGetBuiltinUsedInstancesUnusedBuiltin(t *testing.T) {
+	vm := vm.NewVirtualMachine()
+	vm.BuiltinRunners = append(vm.BuiltinRunners, builtins.NewBitwiseBuiltinRunner(256))
+	vm.BuiltinRunners[0].InitializeSegments(&vm.Segments)
+
+	usedInstances, err := vm.GetBuiltinUsedInstances()
+	if err != nil {
+		t.Errorf("GetBuiltinUsedInstances failed with error: %s", err)
+	}
+	expected := map[string]uint{builtins.BITWISE_BUILTIN_NAME: 0}
+	if !reflect.DeepEqual(usedInstances, expected) {
+		t.Errorf("Wrong used instances. Expected %v, got %v", expected, usedInstances)
+	}
+}
+
+func TestRunContextString(t *testing.T) {
+	runContext := vm.RunContext{
+		Pc: memory.NewRelocatable(0, 4),
+		Ap: memory.NewRelocatable(1, 7),
+		Fp: memory.NewRelocatable(1, 2),
+	}
+	expected := "pc: {0:4}, ap: {1:7}, fp: {1:2}"
+	if runContext.String() != expected {
+		t.Errorf("Wrong run context string. Expected %s, got %s", expected, runContext.String())
+	}
+}
+
+func TestWatchpointFiresOnce(t *testing.T) {
+	virtualMachine := vm.NewVirtualMachine()
+	virtualMachine.Segments.AddSegment()
+	watched := memory.NewRelocatable(0, 1)
+	calls := 0
+	virtualMachine.AddWatchpoint(watched, func(old *memory.MaybeRelocatable, new *memory.MaybeRelocatable) {
+		calls++
+		if old != nil {
+			t.Errorf("The watched cell was empty, old should be nil, got %v", old)
+		}
+		if *new != *memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7)) {
+			t.Errorf("Wrong new value, expected 7, got %v", new)
+		}
+	})
+	value := memory.NewMaybeRelocatableFelt(lambdaworks.FeltFromUint64(7))
+	virtualMachine.Segments.Memory.Insert(memory.NewRelocatable(0, 0), value)
+	virtualMachine.Segments.Memory.Insert(watched, value)
+	// Rewriting the same value is not a new write
+	virtualMachine.Segments.Memory.Insert(watched, value)
+	if calls != 1 {
+		t.Errorf("The watchpoint should have fired once, fired %d times", calls)
+	}
+}